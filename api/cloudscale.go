@@ -24,6 +24,8 @@ limitations under the License.
 package api
 
 import (
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
 	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
 	v1alpha1 "github.com/vshn/stack-cloudscale/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,6 +34,8 @@ import (
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes,
+		computev1alpha1.SchemeBuilder.AddToScheme,
+		networkv1alpha1.SchemeBuilder.AddToScheme,
 		storagev1alpha1.SchemeBuilder.AddToScheme,
 		v1alpha1.SchemeBuilder.AddToScheme,
 	)
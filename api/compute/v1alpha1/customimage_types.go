@@ -0,0 +1,116 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CustomImageParameters define the desired state of a Cloudscale custom
+// image. The name of the image is its external name. The image is imported
+// from the URL once, a failed import is not retried.
+// https://www.cloudscale.ch/en/api/v1#custom-images
+type CustomImageParameters struct {
+	// URL the image is imported from. Can't be changed after the import
+	// started.
+	URL string `json:"url"`
+
+	// Slug of the image, used to refer to it as "custom:<slug>" in servers.
+	// +optional
+	Slug string `json:"slug,omitempty"`
+
+	// UserDataHandling defines how the user data of servers is passed to the
+	// image. Defaults to pass-through.
+	// +kubebuilder:validation:Enum=pass-through;extend-cloud-config
+	// +optional
+	UserDataHandling string `json:"userDataHandling,omitempty"`
+
+	// FirmwareType the image boots with. Can't be changed after the import
+	// started. Defaults to bios.
+	// +kubebuilder:validation:Enum=bios;uefi
+	// +optional
+	FirmwareType string `json:"firmwareType,omitempty"`
+
+	// Zones the image is available in, e.g. "lpg1". Can't be changed after
+	// the import started.
+	// +kubebuilder:validation:MinItems=1
+	Zones []string `json:"zones"`
+
+	// SourceFormat of the image at the URL. Defaults to raw.
+	// +kubebuilder:validation:Enum=raw
+	// +optional
+	SourceFormat string `json:"sourceFormat,omitempty"`
+}
+
+// CustomImageSpec defines the desired state of CustomImage
+type CustomImageSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  CustomImageParameters `json:"forProvider"`
+}
+
+// CustomImageObservation is the representation of the current state that is observed.
+type CustomImageObservation struct {
+	// UUID of the imported image.
+	UUID string `json:"uuid,omitempty"`
+
+	// ImportUUID is the UUID of the import of the image.
+	ImportUUID string `json:"importUUID,omitempty"`
+
+	// ImportStatus is the status of the import, e.g. "in_progress",
+	// "success" or "failed".
+	ImportStatus string `json:"importStatus,omitempty"`
+
+	SizeGB int      `json:"sizeGB,omitempty"`
+	Zones  []string `json:"zones,omitempty"`
+}
+
+// CustomImageStatus defines the observed state of CustomImage
+type CustomImageStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider CustomImageObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomImage is the Schema for the customimages API
+// +kubebuilder:printcolumn:name="SLUG",type="string",JSONPath=".spec.forProvider.slug"
+// +kubebuilder:printcolumn:name="IMPORT",type="string",JSONPath=".status.atProvider.importStatus"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type CustomImage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomImageSpec   `json:"spec"`
+	Status CustomImageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomImageList contains a list of CustomImage
+type CustomImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomImage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CustomImage{}, &CustomImageList{})
+}
@@ -0,0 +1,88 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+const (
+	// Group is the group of the objects
+	Group = "compute.cloudscale.crossplane.io"
+
+	// Version is the version of the objects
+	Version = "v1alpha1"
+)
+
+var (
+
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+
+	// ServerKind is a convenience variable for the kind string
+	ServerKind = reflect.TypeOf(Server{}).Name()
+
+	// ServerKindAPIVersion is a convenience variable for the API version string
+	ServerKindAPIVersion = ServerKind + "." + GroupVersion.String()
+
+	// ServerGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	ServerGroupVersionKind = GroupVersion.WithKind(ServerKind)
+
+	// VolumeKind is a convenience variable for the kind string
+	VolumeKind = reflect.TypeOf(Volume{}).Name()
+
+	// VolumeKindAPIVersion is a convenience variable for the API version string
+	VolumeKindAPIVersion = VolumeKind + "." + GroupVersion.String()
+
+	// VolumeGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	VolumeGroupVersionKind = GroupVersion.WithKind(VolumeKind)
+
+	// ServerGroupKind is a convenience variable for the kind string
+	ServerGroupKind = reflect.TypeOf(ServerGroup{}).Name()
+
+	// ServerGroupKindAPIVersion is a convenience variable for the API version string
+	ServerGroupKindAPIVersion = ServerGroupKind + "." + GroupVersion.String()
+
+	// ServerGroupGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	ServerGroupGroupVersionKind = GroupVersion.WithKind(ServerGroupKind)
+
+	// CustomImageKind is a convenience variable for the kind string
+	CustomImageKind = reflect.TypeOf(CustomImage{}).Name()
+
+	// CustomImageKindAPIVersion is a convenience variable for the API version string
+	CustomImageKindAPIVersion = CustomImageKind + "." + GroupVersion.String()
+
+	// CustomImageGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	CustomImageGroupVersionKind = GroupVersion.WithKind(CustomImageKind)
+
+	// VolumeSnapshotKind is a convenience variable for the kind string
+	VolumeSnapshotKind = reflect.TypeOf(VolumeSnapshot{}).Name()
+
+	// VolumeSnapshotKindAPIVersion is a convenience variable for the API version string
+	VolumeSnapshotKindAPIVersion = VolumeSnapshotKind + "." + GroupVersion.String()
+
+	// VolumeSnapshotGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	VolumeSnapshotGroupVersionKind = GroupVersion.WithKind(VolumeSnapshotKind)
+)
@@ -0,0 +1,192 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerParameters define the desired state of a Cloudscale server. The name
+// of the server is its external name. Only the name and flavor can be changed
+// after the server was created, changing the flavor restarts the server. All
+// other parameters are immutable, changes to them are not applied to the
+// existing server.
+// https://www.cloudscale.ch/en/api/v1#servers
+type ServerParameters struct {
+	// Flavor of the server, e.g. "flex-4".
+	Flavor string `json:"flavor"`
+
+	// Image the root volume is created from, e.g. "debian-10".
+	// Immutable after the server was created.
+	Image string `json:"image"`
+
+	// Zone of the server, e.g. "lpg1". The default zone of the project is
+	// used if omitted.
+	// Immutable after the server was created.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// VolumeSizeGB is the size of the root volume. Defaults to 10.
+	// Immutable after the server was created.
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	VolumeSizeGB *int `json:"volumeSizeGB,omitempty"`
+
+	// Volumes are additional volumes created with the server.
+	// Immutable after the server was created.
+	// +optional
+	Volumes []ServerVolume `json:"volumes,omitempty"`
+
+	// SSHKeys are the public keys authorized to log in as the SSH user.
+	// Immutable after the server was created.
+	// +optional
+	SSHKeys []string `json:"sshKeys,omitempty"`
+
+	// SSHUser is the user to log in with, published to the connection
+	// secret. It is derived from the image if omitted.
+	// +optional
+	SSHUser *string `json:"sshUser,omitempty"`
+
+	// UserData is passed to cloud-init on the first boot.
+	// Immutable after the server was created.
+	// +optional
+	UserData string `json:"userData,omitempty"`
+
+	// Interfaces of the server. A single interface in the public network is
+	// attached if omitted.
+	// Immutable after the server was created.
+	// +optional
+	Interfaces []ServerInterface `json:"interfaces,omitempty"`
+
+	// ServerGroups are the UUIDs of the server groups the server is a member
+	// of.
+	// Immutable after the server was created.
+	// +optional
+	ServerGroups []string `json:"serverGroups,omitempty"`
+
+	// ServerGroupRefs reference the ServerGroups the server is a member of,
+	// in addition to ServerGroups.
+	// Immutable after the server was created.
+	// +optional
+	ServerGroupRefs []corev1.LocalObjectReference `json:"serverGroupRefs,omitempty"`
+}
+
+// A ServerVolume is an additional volume of a server.
+type ServerVolume struct {
+	// SizeGB is the size of the volume.
+	// +kubebuilder:validation:Minimum=1
+	SizeGB int `json:"sizeGB"`
+
+	// Type of the volume.
+	// +kubebuilder:validation:Enum=ssd;bulk
+	// +optional
+	Type string `json:"type,omitempty"`
+}
+
+// A ServerInterface attaches a server to a network.
+type ServerInterface struct {
+	// Network is "public" or the UUID of a private network.
+	Network string `json:"network"`
+
+	// Addresses of the interface in the subnets of a private network. An
+	// address is assigned automatically if omitted.
+	// +optional
+	Addresses []ServerInterfaceAddress `json:"addresses,omitempty"`
+}
+
+// A ServerInterfaceAddress is the address of an interface in a subnet.
+type ServerInterfaceAddress struct {
+	// Subnet is the UUID of the subnet.
+	Subnet string `json:"subnet"`
+
+	// Address in the subnet. A free address is assigned if omitted.
+	// +optional
+	Address string `json:"address,omitempty"`
+}
+
+// ServerSpec defines the desired state of Server
+type ServerSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  ServerParameters `json:"forProvider"`
+}
+
+// ServerObservation is the representation of the current state that is observed.
+type ServerObservation struct {
+	UUID string `json:"uuid,omitempty"`
+
+	// State of the server, e.g. "running" or "stopped".
+	State string `json:"state,omitempty"`
+
+	// Zone the server is running in.
+	Zone string `json:"zone,omitempty"`
+
+	// PublicIPv4 and PublicIPv6 are the addresses of the server in the public
+	// network.
+	PublicIPv4 string `json:"publicIPv4,omitempty"`
+	PublicIPv6 string `json:"publicIPv6,omitempty"`
+
+	// Interfaces of the server and their addresses.
+	Interfaces []ServerInterfaceObservation `json:"interfaces,omitempty"`
+}
+
+// ServerInterfaceObservation is the observed state of an interface.
+type ServerInterfaceObservation struct {
+	// Type of the network, "public" or "private".
+	Type      string   `json:"type"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// ServerStatus defines the observed state of Server
+type ServerStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider ServerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Server is the Schema for the servers API. The public IP and the SSH user of
+// the server are published to its connection secret.
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="FLAVOR",type="string",JSONPath=".spec.forProvider.flavor"
+// +kubebuilder:printcolumn:name="IP",type="string",JSONPath=".status.atProvider.publicIPv4"
+// +kubebuilder:printcolumn:name="ZONE",type="string",JSONPath=".status.atProvider.zone"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type Server struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerSpec   `json:"spec"`
+	Status ServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerList contains a list of Server
+type ServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Server `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Server{}, &ServerList{})
+}
@@ -0,0 +1,91 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerGroupParameters define the desired state of a Cloudscale server
+// group. The name of the server group is its external name. Servers join a
+// group when they are created, see ServerParameters.
+// https://www.cloudscale.ch/en/api/v1#server-groups
+type ServerGroupParameters struct {
+	// Type of the server group. Servers of an anti-affinity group run on
+	// different physical hosts.
+	// +kubebuilder:validation:Enum=anti-affinity
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Zone of the server group, e.g. "lpg1". The default zone of the project
+	// is used if omitted.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// ServerGroupSpec defines the desired state of ServerGroup
+type ServerGroupSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  ServerGroupParameters `json:"forProvider,omitempty"`
+}
+
+// ServerGroupObservation is the representation of the current state that is observed.
+type ServerGroupObservation struct {
+	UUID string `json:"uuid,omitempty"`
+	Zone string `json:"zone,omitempty"`
+
+	// Servers are the UUIDs of the member servers.
+	Servers []string `json:"servers,omitempty"`
+}
+
+// ServerGroupStatus defines the observed state of ServerGroup
+type ServerGroupStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider ServerGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerGroup is the Schema for the servergroups API. A server group can only
+// be deleted once it has no members.
+// +kubebuilder:printcolumn:name="UUID",type="string",JSONPath=".status.atProvider.uuid"
+// +kubebuilder:printcolumn:name="ZONE",type="string",JSONPath=".status.atProvider.zone"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type ServerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerGroupSpec   `json:"spec,omitempty"`
+	Status ServerGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerGroupList contains a list of ServerGroup
+type ServerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerGroup{}, &ServerGroupList{})
+}
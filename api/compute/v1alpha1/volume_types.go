@@ -0,0 +1,112 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeParameters define the desired state of a Cloudscale volume. The name
+// of the volume is its external name.
+// https://www.cloudscale.ch/en/api/v1#volumes
+type VolumeParameters struct {
+	// SizeGB is the size of the volume. Volumes are grown online, but can't
+	// be shrunk. A volume restored from a snapshot must be at least as large
+	// as the snapshot.
+	// +kubebuilder:validation:Minimum=1
+	SizeGB int `json:"sizeGB"`
+
+	// Type of the volume. Can't be changed after the volume was created.
+	// +kubebuilder:validation:Enum=ssd;bulk
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Zone of the volume, e.g. "lpg1". The default zone of the project is
+	// used if omitted.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// ServerUUIDs are the UUIDs of the servers the volume is attached to.
+	// +optional
+	ServerUUIDs []string `json:"serverUUIDs,omitempty"`
+
+	// SnapshotUUID is the UUID of the snapshot the volume is restored from
+	// when it is created.
+	// +optional
+	SnapshotUUID string `json:"snapshotUUID,omitempty"`
+
+	// SnapshotRef references the VolumeSnapshot the volume is restored from
+	// when it is created. Takes precedence over SnapshotUUID.
+	// +optional
+	SnapshotRef *corev1.LocalObjectReference `json:"snapshotRef,omitempty"`
+}
+
+// VolumeSpec defines the desired state of Volume
+type VolumeSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  VolumeParameters `json:"forProvider"`
+}
+
+// VolumeObservation is the representation of the current state that is observed.
+type VolumeObservation struct {
+	UUID   string `json:"uuid,omitempty"`
+	SizeGB int    `json:"sizeGB,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Zone   string `json:"zone,omitempty"`
+
+	// ServerUUIDs are the UUIDs of the servers the volume is attached to.
+	ServerUUIDs []string `json:"serverUUIDs,omitempty"`
+}
+
+// VolumeStatus defines the observed state of Volume
+type VolumeStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider VolumeObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Volume is the Schema for the volumes API
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.sizeGB"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="ZONE",type="string",JSONPath=".status.atProvider.zone"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type Volume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeSpec   `json:"spec"`
+	Status VolumeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeList contains a list of Volume
+type VolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Volume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Volume{}, &VolumeList{})
+}
@@ -0,0 +1,88 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeSnapshotParameters define the desired state of a Cloudscale volume
+// snapshot. The name of the snapshot is its external name. Only the name can
+// be changed after the snapshot was taken.
+// https://www.cloudscale.ch/en/api/v1#volume-snapshots
+type VolumeSnapshotParameters struct {
+	// SourceVolume is the UUID of the volume to snapshot.
+	SourceVolume string `json:"sourceVolume"`
+}
+
+// VolumeSnapshotSpec defines the desired state of VolumeSnapshot
+type VolumeSnapshotSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  VolumeSnapshotParameters `json:"forProvider"`
+}
+
+// VolumeSnapshotObservation is the representation of the current state that is observed.
+type VolumeSnapshotObservation struct {
+	UUID             string `json:"uuid,omitempty"`
+	SourceVolumeUUID string `json:"sourceVolumeUUID,omitempty"`
+	Zone             string `json:"zone,omitempty"`
+	SizeGB           int    `json:"sizeGB,omitempty"`
+
+	// State of the snapshot, e.g. "creating" or "available".
+	State string `json:"state,omitempty"`
+
+	// CreatedAt is the time the snapshot was taken.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+}
+
+// VolumeSnapshotStatus defines the observed state of VolumeSnapshot
+type VolumeSnapshotStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider VolumeSnapshotObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeSnapshot is the Schema for the volumesnapshots API
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.sizeGB"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="CREATED",type="date",JSONPath=".status.atProvider.createdAt"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type VolumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeSnapshotSpec   `json:"spec"`
+	Status VolumeSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeSnapshotList contains a list of VolumeSnapshot
+type VolumeSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolumeSnapshot{}, &VolumeSnapshotList{})
+}
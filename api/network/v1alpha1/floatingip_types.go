@@ -0,0 +1,120 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FloatingIPParameters define the desired state of a Cloudscale floating IP.
+// The address is assigned by Cloudscale, only the server and the reverse
+// pointer can be changed after the floating IP was created.
+// https://www.cloudscale.ch/en/api/v1#floating-ips
+type FloatingIPParameters struct {
+	// IPVersion of the floating IP.
+	// +kubebuilder:validation:Enum=4;6
+	IPVersion int `json:"ipVersion"`
+
+	// PrefixLength of the floating network. A single address is assigned if
+	// omitted. Only supported for IPv6.
+	// +optional
+	PrefixLength *int `json:"prefixLength,omitempty"`
+
+	// Type of the floating IP. Global floating IPs can be assigned to servers
+	// in all regions. Defaults to regional.
+	// +kubebuilder:validation:Enum=regional;global
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Region of a regional floating IP. The region of the server is used if
+	// omitted.
+	// +kubebuilder:validation:Enum=lpg;rma
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// ReversePointer is the PTR record of the address.
+	// +optional
+	ReversePointer *string `json:"reversePointer,omitempty"`
+
+	// Server is the UUID of the server the floating IP is assigned to.
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// ServerRef references the Server the floating IP is assigned to. Takes
+	// precedence over Server.
+	// +optional
+	ServerRef *corev1.LocalObjectReference `json:"serverRef,omitempty"`
+}
+
+// FloatingIPSpec defines the desired state of FloatingIP
+type FloatingIPSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  FloatingIPParameters `json:"forProvider"`
+}
+
+// FloatingIPObservation is the representation of the current state that is observed.
+type FloatingIPObservation struct {
+	// Address is the floating IP, the first address of Network.
+	Address string `json:"address,omitempty"`
+
+	// Network is the floating network in CIDR notation.
+	Network string `json:"network,omitempty"`
+
+	// ServerUUID is the UUID of the server the floating IP is assigned to.
+	ServerUUID string `json:"serverUUID,omitempty"`
+
+	ReversePointer string `json:"reversePointer,omitempty"`
+}
+
+// FloatingIPStatus defines the observed state of FloatingIP
+type FloatingIPStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider FloatingIPObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FloatingIP is the Schema for the floatingips API. The address is stored as
+// the external name and published to its connection secret.
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".status.atProvider.address"
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".status.atProvider.serverUUID"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type FloatingIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FloatingIPSpec   `json:"spec"`
+	Status FloatingIPStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FloatingIPList contains a list of FloatingIP
+type FloatingIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FloatingIP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FloatingIP{}, &FloatingIPList{})
+}
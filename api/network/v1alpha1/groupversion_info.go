@@ -0,0 +1,115 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+const (
+	// Group is the group of the objects
+	Group = "network.cloudscale.crossplane.io"
+
+	// Version is the version of the objects
+	Version = "v1alpha1"
+)
+
+var (
+
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+
+	// NetworkKind is a convenience variable for the kind string
+	NetworkKind = reflect.TypeOf(Network{}).Name()
+
+	// NetworkKindAPIVersion is a convenience variable for the API version string
+	NetworkKindAPIVersion = NetworkKind + "." + GroupVersion.String()
+
+	// NetworkGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	NetworkGroupVersionKind = GroupVersion.WithKind(NetworkKind)
+
+	// SubnetKind is a convenience variable for the kind string
+	SubnetKind = reflect.TypeOf(Subnet{}).Name()
+
+	// SubnetKindAPIVersion is a convenience variable for the API version string
+	SubnetKindAPIVersion = SubnetKind + "." + GroupVersion.String()
+
+	// SubnetGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	SubnetGroupVersionKind = GroupVersion.WithKind(SubnetKind)
+
+	// FloatingIPKind is a convenience variable for the kind string
+	FloatingIPKind = reflect.TypeOf(FloatingIP{}).Name()
+
+	// FloatingIPKindAPIVersion is a convenience variable for the API version string
+	FloatingIPKindAPIVersion = FloatingIPKind + "." + GroupVersion.String()
+
+	// FloatingIPGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	FloatingIPGroupVersionKind = GroupVersion.WithKind(FloatingIPKind)
+
+	// LoadBalancerKind is a convenience variable for the kind string
+	LoadBalancerKind = reflect.TypeOf(LoadBalancer{}).Name()
+
+	// LoadBalancerKindAPIVersion is a convenience variable for the API version string
+	LoadBalancerKindAPIVersion = LoadBalancerKind + "." + GroupVersion.String()
+
+	// LoadBalancerGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	LoadBalancerGroupVersionKind = GroupVersion.WithKind(LoadBalancerKind)
+
+	// LoadBalancerPoolKind is a convenience variable for the kind string
+	LoadBalancerPoolKind = reflect.TypeOf(LoadBalancerPool{}).Name()
+
+	// LoadBalancerPoolKindAPIVersion is a convenience variable for the API version string
+	LoadBalancerPoolKindAPIVersion = LoadBalancerPoolKind + "." + GroupVersion.String()
+
+	// LoadBalancerPoolGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	LoadBalancerPoolGroupVersionKind = GroupVersion.WithKind(LoadBalancerPoolKind)
+
+	// LoadBalancerPoolMemberKind is a convenience variable for the kind string
+	LoadBalancerPoolMemberKind = reflect.TypeOf(LoadBalancerPoolMember{}).Name()
+
+	// LoadBalancerPoolMemberKindAPIVersion is a convenience variable for the API version string
+	LoadBalancerPoolMemberKindAPIVersion = LoadBalancerPoolMemberKind + "." + GroupVersion.String()
+
+	// LoadBalancerPoolMemberGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	LoadBalancerPoolMemberGroupVersionKind = GroupVersion.WithKind(LoadBalancerPoolMemberKind)
+
+	// LoadBalancerListenerKind is a convenience variable for the kind string
+	LoadBalancerListenerKind = reflect.TypeOf(LoadBalancerListener{}).Name()
+
+	// LoadBalancerListenerKindAPIVersion is a convenience variable for the API version string
+	LoadBalancerListenerKindAPIVersion = LoadBalancerListenerKind + "." + GroupVersion.String()
+
+	// LoadBalancerListenerGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	LoadBalancerListenerGroupVersionKind = GroupVersion.WithKind(LoadBalancerListenerKind)
+
+	// LoadBalancerHealthMonitorKind is a convenience variable for the kind string
+	LoadBalancerHealthMonitorKind = reflect.TypeOf(LoadBalancerHealthMonitor{}).Name()
+
+	// LoadBalancerHealthMonitorKindAPIVersion is a convenience variable for the API version string
+	LoadBalancerHealthMonitorKindAPIVersion = LoadBalancerHealthMonitorKind + "." + GroupVersion.String()
+
+	// LoadBalancerHealthMonitorGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	LoadBalancerHealthMonitorGroupVersionKind = GroupVersion.WithKind(LoadBalancerHealthMonitorKind)
+)
@@ -0,0 +1,118 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadBalancerParameters define the desired state of a Cloudscale load
+// balancer. The name of the load balancer is its external name. Only the name
+// can be changed after the load balancer was created.
+// https://www.cloudscale.ch/en/api/v1#load-balancers
+type LoadBalancerParameters struct {
+	// Flavor of the load balancer. Defaults to lb-standard.
+	// +optional
+	Flavor string `json:"flavor,omitempty"`
+
+	// Zone of the load balancer, e.g. "lpg1". The default zone of the project
+	// is used if omitted.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// VIPAddresses of the load balancer in private subnets. A public address
+	// is assigned if omitted.
+	// +optional
+	VIPAddresses []VIPAddress `json:"vipAddresses,omitempty"`
+}
+
+// A VIPAddress is a virtual IP of a load balancer in a subnet.
+type VIPAddress struct {
+	// Subnet is the UUID of the subnet of the address.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
+
+	// SubnetRef references the Subnet of the address. Takes precedence over
+	// Subnet.
+	// +optional
+	SubnetRef *corev1.LocalObjectReference `json:"subnetRef,omitempty"`
+
+	// Address in the subnet. A free address is assigned if omitted.
+	// +optional
+	Address string `json:"address,omitempty"`
+}
+
+// LoadBalancerObservation is the representation of the current state that is observed.
+type LoadBalancerObservation struct {
+	UUID string `json:"uuid,omitempty"`
+	Zone string `json:"zone,omitempty"`
+
+	// ProvisioningStatus of the load balancer, e.g. "running" or "changing".
+	ProvisioningStatus string `json:"provisioningStatus,omitempty"`
+
+	// OperatingStatus of the load balancer, e.g. "online", "degraded" or "offline".
+	OperatingStatus string `json:"operatingStatus,omitempty"`
+
+	// VIPAddresses are the virtual IPs of the load balancer.
+	VIPAddresses []string `json:"vipAddresses,omitempty"`
+}
+
+// LoadBalancerSpec defines the desired state of LoadBalancer
+type LoadBalancerSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  LoadBalancerParameters `json:"forProvider,omitempty"`
+}
+
+// LoadBalancerStatus defines the observed state of LoadBalancer
+type LoadBalancerStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider LoadBalancerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancer is the Schema for the loadbalancers API. The first VIP address is published
+// to its connection secret.
+// +kubebuilder:printcolumn:name="VIP",type="string",JSONPath=".status.atProvider.vipAddresses[0]"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.provisioningStatus"
+// +kubebuilder:printcolumn:name="OPERATING",type="string",JSONPath=".status.atProvider.operatingStatus"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LoadBalancer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerSpec   `json:"spec,omitempty"`
+	Status LoadBalancerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerList contains a list of LoadBalancer
+type LoadBalancerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancer{}, &LoadBalancerList{})
+}
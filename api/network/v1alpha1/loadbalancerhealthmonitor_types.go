@@ -0,0 +1,138 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadBalancerHealthMonitorParameters define the desired state of the health
+// monitor of a pool of a Cloudscale load balancer. Health monitors have no
+// name, a pool has at most one health monitor. The pool and type can't be
+// changed after the health monitor was created.
+// https://www.cloudscale.ch/en/api/v1#load-balancer-health-monitors
+type LoadBalancerHealthMonitorParameters struct {
+	// PoolRef references the LoadBalancerPool whose members are checked.
+	PoolRef *corev1.LocalObjectReference `json:"poolRef"`
+
+	// Type of the check.
+	// +kubebuilder:validation:Enum=ping;tcp;http;https;tls-hello
+	Type string `json:"type"`
+
+	// DelayS is the time between two checks.
+	// +optional
+	DelayS *int `json:"delayS,omitempty"`
+
+	// TimeoutS is the time a check waits for a response.
+	// +optional
+	TimeoutS *int `json:"timeoutS,omitempty"`
+
+	// UpThreshold is the number of successful checks after which a member is
+	// up.
+	// +optional
+	UpThreshold *int `json:"upThreshold,omitempty"`
+
+	// DownThreshold is the number of failed checks after which a member is
+	// down.
+	// +optional
+	DownThreshold *int `json:"downThreshold,omitempty"`
+
+	// HTTP configures the checks of the http and https types.
+	// +optional
+	HTTP *HealthMonitorHTTP `json:"http,omitempty"`
+}
+
+// HealthMonitorHTTP configures an HTTP health check.
+type HealthMonitorHTTP struct {
+	// ExpectedCodes are the HTTP status codes of a successful check.
+	// +optional
+	ExpectedCodes []string `json:"expectedCodes,omitempty"`
+
+	// Method of the request.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// URLPath of the request.
+	// +optional
+	URLPath string `json:"urlPath,omitempty"`
+
+	// Version of HTTP.
+	// +kubebuilder:validation:Enum="1.0";"1.1"
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Host header of the request, required for HTTP/1.1.
+	// +optional
+	Host *string `json:"host,omitempty"`
+}
+
+// LoadBalancerHealthMonitorObservation is the representation of the current state that is observed.
+type LoadBalancerHealthMonitorObservation struct {
+	UUID     string `json:"uuid,omitempty"`
+	PoolUUID string `json:"poolUUID,omitempty"`
+
+	// ProvisioningStatus of the health monitor, e.g. "running" or "changing".
+	ProvisioningStatus string `json:"provisioningStatus,omitempty"`
+
+	// OperatingStatus of the health monitor, e.g. "online", "degraded" or "offline".
+	OperatingStatus string `json:"operatingStatus,omitempty"`
+}
+
+// LoadBalancerHealthMonitorSpec defines the desired state of LoadBalancerHealthMonitor
+type LoadBalancerHealthMonitorSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  LoadBalancerHealthMonitorParameters `json:"forProvider"`
+}
+
+// LoadBalancerHealthMonitorStatus defines the observed state of LoadBalancerHealthMonitor
+type LoadBalancerHealthMonitorStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider LoadBalancerHealthMonitorObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerHealthMonitor is the Schema for the loadbalancerhealthmonitors API.
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.forProvider.type"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.provisioningStatus"
+// +kubebuilder:printcolumn:name="OPERATING",type="string",JSONPath=".status.atProvider.operatingStatus"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LoadBalancerHealthMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerHealthMonitorSpec   `json:"spec"`
+	Status LoadBalancerHealthMonitorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerHealthMonitorList contains a list of LoadBalancerHealthMonitor
+type LoadBalancerHealthMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerHealthMonitor `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerHealthMonitor{}, &LoadBalancerHealthMonitorList{})
+}
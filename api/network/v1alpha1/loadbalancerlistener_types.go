@@ -0,0 +1,114 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadBalancerListenerParameters define the desired state of a listener of a
+// Cloudscale load balancer. The name of the listener is its external name. The
+// pool and protocol can't be changed after the listener was created.
+// https://www.cloudscale.ch/en/api/v1#load-balancer-listeners
+type LoadBalancerListenerParameters struct {
+	// PoolRef references the LoadBalancerPool the listener forwards to.
+	PoolRef *corev1.LocalObjectReference `json:"poolRef"`
+
+	// Protocol of the listener.
+	// +kubebuilder:validation:Enum=tcp
+	Protocol string `json:"protocol"`
+
+	// ProtocolPort the listener accepts connections on.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ProtocolPort int `json:"protocolPort"`
+
+	// AllowedCIDRs the listener accepts connections from. Connections from
+	// everywhere are accepted if omitted.
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+
+	// TimeoutClientDataMS is the idle timeout of client connections.
+	// +optional
+	TimeoutClientDataMS *int `json:"timeoutClientDataMS,omitempty"`
+
+	// TimeoutMemberConnectMS is the timeout of connecting to a member.
+	// +optional
+	TimeoutMemberConnectMS *int `json:"timeoutMemberConnectMS,omitempty"`
+
+	// TimeoutMemberDataMS is the idle timeout of member connections.
+	// +optional
+	TimeoutMemberDataMS *int `json:"timeoutMemberDataMS,omitempty"`
+}
+
+// LoadBalancerListenerObservation is the representation of the current state that is observed.
+type LoadBalancerListenerObservation struct {
+	UUID             string `json:"uuid,omitempty"`
+	PoolUUID         string `json:"poolUUID,omitempty"`
+	LoadBalancerUUID string `json:"loadBalancerUUID,omitempty"`
+
+	// ProvisioningStatus of the listener, e.g. "running" or "changing".
+	ProvisioningStatus string `json:"provisioningStatus,omitempty"`
+
+	// OperatingStatus of the listener, e.g. "online", "degraded" or "offline".
+	OperatingStatus string `json:"operatingStatus,omitempty"`
+}
+
+// LoadBalancerListenerSpec defines the desired state of LoadBalancerListener
+type LoadBalancerListenerSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  LoadBalancerListenerParameters `json:"forProvider"`
+}
+
+// LoadBalancerListenerStatus defines the observed state of LoadBalancerListener
+type LoadBalancerListenerStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider LoadBalancerListenerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerListener is the Schema for the loadbalancerlisteners API.
+// +kubebuilder:printcolumn:name="PORT",type="integer",JSONPath=".spec.forProvider.protocolPort"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.provisioningStatus"
+// +kubebuilder:printcolumn:name="OPERATING",type="string",JSONPath=".status.atProvider.operatingStatus"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LoadBalancerListener struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerListenerSpec   `json:"spec"`
+	Status LoadBalancerListenerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerListenerList contains a list of LoadBalancerListener
+type LoadBalancerListenerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerListener `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerListener{}, &LoadBalancerListenerList{})
+}
@@ -0,0 +1,95 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadBalancerPoolParameters define the desired state of a pool of a
+// Cloudscale load balancer. The name of the pool is its external name. Only
+// the name can be changed after the pool was created.
+// https://www.cloudscale.ch/en/api/v1#load-balancer-pools
+type LoadBalancerPoolParameters struct {
+	// LoadBalancerRef references the LoadBalancer of the pool.
+	LoadBalancerRef *corev1.LocalObjectReference `json:"loadBalancerRef"`
+
+	// Algorithm distributing the connections to the members.
+	// +kubebuilder:validation:Enum=round_robin;least_connections;source_ip
+	Algorithm string `json:"algorithm"`
+
+	// Protocol between the load balancer and the members.
+	// +kubebuilder:validation:Enum=tcp;proxy;proxyv2
+	Protocol string `json:"protocol"`
+}
+
+// LoadBalancerPoolObservation is the representation of the current state that is observed.
+type LoadBalancerPoolObservation struct {
+	UUID             string `json:"uuid,omitempty"`
+	LoadBalancerUUID string `json:"loadBalancerUUID,omitempty"`
+
+	// ProvisioningStatus of the pool, e.g. "running" or "changing".
+	ProvisioningStatus string `json:"provisioningStatus,omitempty"`
+
+	// OperatingStatus of the pool, e.g. "online", "degraded" or "offline".
+	OperatingStatus string `json:"operatingStatus,omitempty"`
+}
+
+// LoadBalancerPoolSpec defines the desired state of LoadBalancerPool
+type LoadBalancerPoolSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  LoadBalancerPoolParameters `json:"forProvider"`
+}
+
+// LoadBalancerPoolStatus defines the observed state of LoadBalancerPool
+type LoadBalancerPoolStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider LoadBalancerPoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerPool is the Schema for the loadbalancerpools API.
+// +kubebuilder:printcolumn:name="ALGORITHM",type="string",JSONPath=".spec.forProvider.algorithm"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.provisioningStatus"
+// +kubebuilder:printcolumn:name="OPERATING",type="string",JSONPath=".status.atProvider.operatingStatus"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LoadBalancerPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerPoolSpec   `json:"spec"`
+	Status LoadBalancerPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerPoolList contains a list of LoadBalancerPool
+type LoadBalancerPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerPool{}, &LoadBalancerPoolList{})
+}
@@ -0,0 +1,115 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadBalancerPoolMemberParameters define the desired state of a member of a
+// pool of a Cloudscale load balancer. The name of the member is its external
+// name. The address and subnet can't be changed after the member was created.
+// https://www.cloudscale.ch/en/api/v1#load-balancer-pool-members
+type LoadBalancerPoolMemberParameters struct {
+	// PoolRef references the LoadBalancerPool of the member.
+	PoolRef *corev1.LocalObjectReference `json:"poolRef"`
+
+	// Enabled members receive connections. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ProtocolPort the member receives connections on.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ProtocolPort int `json:"protocolPort"`
+
+	// MonitorPort the health monitor checks. Defaults to the protocol port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	MonitorPort *int `json:"monitorPort,omitempty"`
+
+	// Address of the member.
+	Address string `json:"address"`
+
+	// Subnet is the UUID of the subnet of the address.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
+
+	// SubnetRef references the Subnet of the address. Takes precedence over
+	// Subnet.
+	// +optional
+	SubnetRef *corev1.LocalObjectReference `json:"subnetRef,omitempty"`
+}
+
+// LoadBalancerPoolMemberObservation is the representation of the current state that is observed.
+type LoadBalancerPoolMemberObservation struct {
+	UUID     string `json:"uuid,omitempty"`
+	PoolUUID string `json:"poolUUID,omitempty"`
+
+	// ProvisioningStatus of the member, e.g. "running" or "changing".
+	ProvisioningStatus string `json:"provisioningStatus,omitempty"`
+
+	// OperatingStatus of the member as reported by the health monitor, e.g.
+	// "up", "down" or "no_monitor".
+	OperatingStatus string `json:"operatingStatus,omitempty"`
+}
+
+// LoadBalancerPoolMemberSpec defines the desired state of LoadBalancerPoolMember
+type LoadBalancerPoolMemberSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  LoadBalancerPoolMemberParameters `json:"forProvider"`
+}
+
+// LoadBalancerPoolMemberStatus defines the observed state of LoadBalancerPoolMember
+type LoadBalancerPoolMemberStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider LoadBalancerPoolMemberObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerPoolMember is the Schema for the loadbalancerpoolmembers API.
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".spec.forProvider.address"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.provisioningStatus"
+// +kubebuilder:printcolumn:name="MONITOR",type="string",JSONPath=".status.atProvider.operatingStatus"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LoadBalancerPoolMember struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerPoolMemberSpec   `json:"spec"`
+	Status LoadBalancerPoolMemberStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerPoolMemberList contains a list of LoadBalancerPoolMember
+type LoadBalancerPoolMemberList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerPoolMember `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerPoolMember{}, &LoadBalancerPoolMemberList{})
+}
@@ -0,0 +1,95 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkParameters define the desired state of a Cloudscale private network.
+// The name of the network is its external name.
+// https://www.cloudscale.ch/en/api/v1#networks
+type NetworkParameters struct {
+	// MTU of the network. Defaults to 9000.
+	// +kubebuilder:validation:Minimum=1280
+	// +kubebuilder:validation:Maximum=9000
+	// +optional
+	MTU *int `json:"mtu,omitempty"`
+
+	// Zone of the network, e.g. "lpg1". The default zone of the project is
+	// used if omitted.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// AutoCreateIPv4Subnet creates an IPv4 subnet with the network. Defaults
+	// to true, set to false to declare the subnets with Subnets.
+	// +optional
+	AutoCreateIPv4Subnet *bool `json:"autoCreateIPv4Subnet,omitempty"`
+}
+
+// NetworkSpec defines the desired state of Network
+type NetworkSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  NetworkParameters `json:"forProvider,omitempty"`
+}
+
+// NetworkObservation is the representation of the current state that is observed.
+type NetworkObservation struct {
+	UUID string `json:"uuid,omitempty"`
+	MTU  int    `json:"mtu,omitempty"`
+	Zone string `json:"zone,omitempty"`
+
+	// Subnets are the CIDRs of the subnets of the network.
+	Subnets []string `json:"subnets,omitempty"`
+}
+
+// NetworkStatus defines the observed state of Network
+type NetworkStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider NetworkObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Network is the Schema for the networks API
+// +kubebuilder:printcolumn:name="UUID",type="string",JSONPath=".status.atProvider.uuid"
+// +kubebuilder:printcolumn:name="ZONE",type="string",JSONPath=".status.atProvider.zone"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type Network struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkSpec   `json:"spec,omitempty"`
+	Status NetworkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkList contains a list of Network
+type NetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Network `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Network{}, &NetworkList{})
+}
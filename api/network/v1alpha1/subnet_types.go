@@ -0,0 +1,101 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SubnetParameters define the desired state of a subnet of a Cloudscale
+// private network. The CIDR and network can't be changed after the subnet
+// was created.
+// https://www.cloudscale.ch/en/api/v1#subnets
+type SubnetParameters struct {
+	// CIDR of the subnet, e.g. "10.11.12.0/24".
+	CIDR string `json:"cidr"`
+
+	// Network is the UUID of the network of the subnet.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// NetworkRef references the Network of the subnet. Takes precedence over
+	// Network.
+	// +optional
+	NetworkRef *corev1.LocalObjectReference `json:"networkRef,omitempty"`
+
+	// GatewayAddress of the subnet. Servers get no default route through the
+	// private network if omitted.
+	// +optional
+	GatewayAddress *string `json:"gatewayAddress,omitempty"`
+
+	// DNSServers announced by DHCP. The Cloudscale resolvers are used if
+	// omitted.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+}
+
+// SubnetSpec defines the desired state of Subnet
+type SubnetSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  SubnetParameters `json:"forProvider"`
+}
+
+// SubnetObservation is the representation of the current state that is observed.
+type SubnetObservation struct {
+	UUID           string   `json:"uuid,omitempty"`
+	NetworkUUID    string   `json:"networkUUID,omitempty"`
+	GatewayAddress string   `json:"gatewayAddress,omitempty"`
+	DNSServers     []string `json:"dnsServers,omitempty"`
+}
+
+// SubnetStatus defines the observed state of Subnet
+type SubnetStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider SubnetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Subnet is the Schema for the subnets API
+// +kubebuilder:printcolumn:name="CIDR",type="string",JSONPath=".spec.forProvider.cidr"
+// +kubebuilder:printcolumn:name="GATEWAY",type="string",JSONPath=".status.atProvider.gatewayAddress"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type Subnet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubnetSpec   `json:"spec"`
+	Status SubnetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SubnetList contains a list of Subnet
+type SubnetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subnet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Subnet{}, &SubnetList{})
+}
@@ -58,4 +58,11 @@ var (
 
 	// S3BucketClassGroupVersionKind is a convenience variable to generate the GroupVersionKind
 	S3BucketClassGroupVersionKind = GroupVersion.WithKind(S3BucketClassKind)
+
+	// ObjectsUserKind is a convenience variable for the kind string
+	ObjectsUserKind = reflect.TypeOf(ObjectsUser{}).Name()
+	// ObjectsUserKindAPIVersion is a convenience variable for the API version string
+	ObjectsUserKindAPIVersion = ObjectsUserKind + "." + GroupVersion.String()
+	// ObjectsUserGroupVersionKind is a convenience variable to generate the GroupVersionKind
+	ObjectsUserGroupVersionKind = GroupVersion.WithKind(ObjectsUserKind)
 )
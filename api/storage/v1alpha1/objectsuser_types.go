@@ -0,0 +1,79 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectsUserParameters define the desired state of a Cloudscale objects user.
+// The display name of the user is its external name.
+// https://www.cloudscale.ch/en/api/v1#objects-users
+type ObjectsUserParameters struct {
+	// Tags are optional key, value pairs to add to the objects user
+	// +optional
+	Tags *map[string]string `json:"tags,omitempty"`
+}
+
+// ObjectsUserSpec defines the desired state of ObjectsUser
+type ObjectsUserSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ForProvider                  ObjectsUserParameters `json:"forProvider,omitempty"`
+}
+
+// ObjectsUserObservation is the representation of the current state that is observed.
+type ObjectsUserObservation struct {
+	ID string `json:"id,omitempty"`
+}
+
+// ObjectsUserStatus defines the observed state of ObjectsUser
+type ObjectsUserStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	AtProvider ObjectsUserObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectsUser is the Schema for the objectsusers API. The credentials of the
+// user are published to its connection secret and can be used for all buckets
+// referencing the user.
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type ObjectsUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ObjectsUserSpec   `json:"spec,omitempty"`
+	Status ObjectsUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectsUserList contains a list of ObjectsUser
+type ObjectsUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectsUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ObjectsUser{}, &ObjectsUserList{})
+}
@@ -17,9 +17,25 @@ package v1alpha1
 
 import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AnnotationDeletionProtection overrides the deletion protection of an
+// S3Bucket if set to "true" or "false".
+const AnnotationDeletionProtection = "cloudscale.crossplane.io/deletion-protection"
+
+// AnnotationRotateKeys triggers a key rotation of an S3Bucket whenever its
+// value changes, e.g. when set to the current time.
+const AnnotationRotateKeys = "cloudscale.crossplane.io/rotate-keys"
+
+// AnnotationAdoptObjectsUser adopts an existing bucket owned by the objects
+// user with the ID or display name in its value. Adopted buckets are never
+// created, and their objects user is neither modified nor deleted. Only the
+// settings explicitly set in the spec of an adopted bucket are managed, all
+// others keep their existing configuration.
+const AnnotationAdoptObjectsUser = "cloudscale.crossplane.io/adopt-objects-user"
+
 // S3BucketParameters define the desired state of a Cloudscale S3 Bucket
 // https://www.cloudscale.ch/en/api/v1#objects-users
 // https://docs.ceph.com/docs/bobtail/radosgw/s3/bucketops/
@@ -33,20 +49,325 @@ type S3BucketParameters struct {
 	// +optional
 	CannedACL *string `json:"cannedACL,omitempty"`
 
+	// Versioning state of the bucket. Once enabled, versioning can only be
+	// suspended but not disabled anymore.
+	// +kubebuilder:validation:Enum=Enabled;Suspended
+	// +optional
+	Versioning *string `json:"versioning,omitempty"`
+
+	// LifecycleRules expire objects and abort incomplete multipart uploads
+	// in the bucket.
+	// +optional
+	LifecycleRules []LifecycleRule `json:"lifecycleRules,omitempty"`
+
+	// CORSRules allow cross-origin requests to the bucket.
+	// +optional
+	CORSRules []CORSRule `json:"corsRules,omitempty"`
+
+	// Policy is a bucket policy document in JSON format. Other objects users
+	// can be referenced as principals with "arn:aws:iam:::user/<user id>".
+	// https://docs.ceph.com/docs/master/radosgw/bucketpolicy/
+	// +optional
+	Policy *string `json:"policy,omitempty"`
+
+	// Website serves the bucket as a static website.
+	// +optional
+	Website *WebsiteConfiguration `json:"website,omitempty"`
+
+	// ForceDestroy deletes all objects, object versions and incomplete
+	// multipart uploads before the bucket is deleted. Otherwise only empty
+	// buckets can be deleted.
+	// +optional
+	ForceDestroy bool `json:"forceDestroy,omitempty"`
+
+	// DeletionProtection prevents the bucket from being deleted, regardless
+	// of the reclaim policy. It can be overridden with the
+	// cloudscale.crossplane.io/deletion-protection annotation.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// UsageSampleLimit is the maximum number of objects listed to measure the
+	// usage of the bucket. The usage is not measured if set to 0. Defaults to
+	// 10000.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	UsageSampleLimit *int64 `json:"usageSampleLimit,omitempty"`
+
+	// ObjectsUserRef references an ObjectsUser which owns the bucket. An
+	// objects user named after the bucket is created if omitted. Tags are
+	// ignored if an ObjectsUser is referenced.
+	// +optional
+	ObjectsUserRef *corev1.LocalObjectReference `json:"objectsUserRef,omitempty"`
+
+	// KeyRotation periodically replaces the keys of the objects user created
+	// for the bucket. Keys can also be rotated on demand by changing the
+	// cloudscale.crossplane.io/rotate-keys annotation. Keys of referenced
+	// ObjectsUsers are never rotated.
+	// +optional
+	KeyRotation *KeyRotation `json:"keyRotation,omitempty"`
+
+	// AccessCredentials are additional credential sets with limited access
+	// to the bucket. Each set is backed by its own objects user, which is
+	// granted access through statements merged into the bucket policy.
+	// +optional
+	AccessCredentials []AccessCredentials `json:"accessCredentials,omitempty"`
+
 	// Region of the bucket.
 	// +kubebuilder:validation:Enum=lpg;rma
 	Region string `json:"region"`
 }
 
+// A LifecycleRule expires objects matching a prefix and tags after a number
+// of days.
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/object-lifecycle-mgmt.html
+type LifecycleRule struct {
+	// ID uniquely identifies the rule within the bucket.
+	ID string `json:"id"`
+
+	// Disabled rules are kept in the bucket configuration but not applied.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Prefix of the object keys the rule applies to.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Tags which objects must have for the rule to apply.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// ExpirationDays after which current objects are expired.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExpirationDays *int64 `json:"expirationDays,omitempty"`
+
+	// NoncurrentVersionExpirationDays after which noncurrent object versions
+	// are deleted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NoncurrentVersionExpirationDays *int64 `json:"noncurrentVersionExpirationDays,omitempty"`
+
+	// AbortIncompleteMultipartUploadDays after which incomplete multipart
+	// uploads are aborted.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AbortIncompleteMultipartUploadDays *int64 `json:"abortIncompleteMultipartUploadDays,omitempty"`
+}
+
+// A KeyRotation replaces the keys of an objects user. A new key is added and
+// published to the connection secret first, the old key is revoked after a
+// grace period.
+type KeyRotation struct {
+	// Interval after which the keys are rotated, e.g. "2160h". Keys are only
+	// rotated on demand if omitted.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// GracePeriod during which the old key stays valid after a rotation.
+	// Defaults to 24h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// BucketAccess is the access granted to a set of access credentials.
+// +kubebuilder:validation:Enum=read;write
+type BucketAccess string
+
+// Supported bucket access levels.
+const (
+	// BucketAccessRead allows listing and downloading objects.
+	BucketAccessRead BucketAccess = "read"
+
+	// BucketAccessWrite allows uploading and deleting objects, but neither
+	// listing nor downloading them.
+	BucketAccessWrite BucketAccess = "write"
+)
+
+// AccessCredentials are a set of credentials with limited access to the
+// bucket.
+type AccessCredentials struct {
+	// Name of the credential set, e.g. "readers". The objects user backing
+	// the set is named <bucket>-<name> and tagged with the UID of the
+	// S3Bucket and the name of the set.
+	Name string `json:"name"`
+
+	// Access granted to the credential set.
+	Access BucketAccess `json:"access"`
+
+	// WriteConnectionSecretToReference specifies the namespace and name of
+	// the Secret the credentials are written to.
+	WriteConnectionSecretToReference runtimev1alpha1.SecretReference `json:"writeConnectionSecretToRef"`
+}
+
+// A CORSRule allows cross-origin requests from a set of origins.
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/cors.html
+type CORSRule struct {
+	// AllowedOrigins from which cross-origin requests are allowed. An origin
+	// may contain at most one "*" wildcard.
+	// +kubebuilder:validation:MinItems=1
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// AllowedMethods which cross-origin requests may use. Valid methods are
+	// GET, PUT, POST, DELETE and HEAD.
+	// +kubebuilder:validation:MinItems=1
+	AllowedMethods []string `json:"allowedMethods"`
+
+	// AllowedHeaders which cross-origin requests may send.
+	// +optional
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+
+	// ExposeHeaders which browsers may access in responses.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAgeSeconds browsers may cache the response to a preflight request.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAgeSeconds *int64 `json:"maxAgeSeconds,omitempty"`
+}
+
+// A WebsiteConfiguration serves a bucket as a static website.
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/WebsiteHosting.html
+type WebsiteConfiguration struct {
+	// IndexDocument is appended to requests for a directory, e.g. index.html.
+	IndexDocument string `json:"indexDocument"`
+
+	// ErrorDocument is the object key returned when a 4XX error occurs.
+	// +optional
+	ErrorDocument string `json:"errorDocument,omitempty"`
+
+	// RoutingRules redirect requests matching a condition.
+	// +optional
+	RoutingRules []RoutingRule `json:"routingRules,omitempty"`
+}
+
+// A RoutingRule redirects website requests matching its condition.
+type RoutingRule struct {
+	// Condition a request has to match to be redirected. All requests are
+	// redirected if omitted.
+	// +optional
+	Condition *RoutingRuleCondition `json:"condition,omitempty"`
+
+	// Redirect describes where matching requests are redirected to.
+	Redirect RoutingRuleRedirect `json:"redirect"`
+}
+
+// A RoutingRuleCondition matches website requests by key prefix or error code.
+type RoutingRuleCondition struct {
+	// KeyPrefixEquals matches requests for object keys with this prefix.
+	// +optional
+	KeyPrefixEquals string `json:"keyPrefixEquals,omitempty"`
+
+	// HTTPErrorCodeReturnedEquals matches requests resulting in this HTTP
+	// error code.
+	// +optional
+	HTTPErrorCodeReturnedEquals string `json:"httpErrorCodeReturnedEquals,omitempty"`
+}
+
+// A RoutingRuleRedirect describes the target of a website redirect.
+type RoutingRuleRedirect struct {
+	// HostName to redirect to.
+	// +optional
+	HostName string `json:"hostName,omitempty"`
+
+	// HTTPRedirectCode of the redirect response, e.g. 301.
+	// +optional
+	HTTPRedirectCode string `json:"httpRedirectCode,omitempty"`
+
+	// Protocol to redirect with.
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// ReplaceKeyPrefixWith replaces the prefix matched by the condition.
+	// +optional
+	ReplaceKeyPrefixWith string `json:"replaceKeyPrefixWith,omitempty"`
+
+	// ReplaceKeyWith replaces the whole object key.
+	// +optional
+	ReplaceKeyWith string `json:"replaceKeyWith,omitempty"`
+}
+
+// A ConnectionSecretFormat is an additional format of the bucket credentials
+// published to the connection secret.
+// +kubebuilder:validation:Enum=aws-credentials;rclone;s3cmd;env
+type ConnectionSecretFormat string
+
+// Supported connection secret formats.
+const (
+	// ConnectionSecretFormatAWSCredentials publishes an AWS shared
+	// credentials file with the key "credentials".
+	ConnectionSecretFormatAWSCredentials ConnectionSecretFormat = "aws-credentials"
+
+	// ConnectionSecretFormatRclone publishes an rclone config with a remote
+	// named "cloudscale" with the key "rclone.conf".
+	ConnectionSecretFormatRclone ConnectionSecretFormat = "rclone"
+
+	// ConnectionSecretFormatS3cmd publishes an s3cmd config with the key
+	// ".s3cfg".
+	ConnectionSecretFormatS3cmd ConnectionSecretFormat = "s3cmd"
+
+	// ConnectionSecretFormatEnv publishes the AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY, AWS_DEFAULT_REGION and AWS_ENDPOINT_URL keys to
+	// be used as environment variables.
+	ConnectionSecretFormatEnv ConnectionSecretFormat = "env"
+)
+
 // S3BucketSpec defines the desired state of S3Bucket
 type S3BucketSpec struct {
 	runtimev1alpha1.ResourceSpec `json:",inline"`
 	ForProvider                  S3BucketParameters `json:"forProvider,omitempty"`
+
+	// ConnectionSecretFormats are published to the connection secret in
+	// addition to the username, password, endpoint, host, region and
+	// bucketname keys.
+	// +optional
+	ConnectionSecretFormats []ConnectionSecretFormat `json:"connectionSecretFormats,omitempty"`
 }
 
 // S3BucketObservation is the representation of the current state that is observed.
 type S3BucketObservation struct {
 	ObjectUserID string `json:"objectUserId,omitempty"`
+
+	// SizeBytes is the total size of all objects in the bucket.
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// ObjectCount is the number of objects in the bucket.
+	ObjectCount int64 `json:"objectCount,omitempty"`
+
+	// UsageTruncated is true if the bucket contains more objects than the
+	// usage sample limit. The size and object count are lower bounds then.
+	UsageTruncated bool `json:"usageTruncated,omitempty"`
+
+	// LastMeasured is the time the usage was last measured.
+	LastMeasured *metav1.Time `json:"lastMeasured,omitempty"`
+
+	// AccessKey is the access key published to the connection secret.
+	AccessKey string `json:"accessKey,omitempty"`
+
+	// LastKeyRotation is the time the keys were last rotated.
+	LastKeyRotation *metav1.Time `json:"lastKeyRotation,omitempty"`
+
+	// LastKeyRotationTrigger is the value of the rotate-keys annotation which
+	// triggered the last rotation.
+	LastKeyRotationTrigger string `json:"lastKeyRotationTrigger,omitempty"`
+
+	// RetiredAccessKey is the access key replaced by the last rotation. It is
+	// revoked at RetiredKeyRevocation.
+	RetiredAccessKey string `json:"retiredAccessKey,omitempty"`
+
+	// RetiredKeyRevocation is the time the retired access key is revoked.
+	RetiredKeyRevocation *metav1.Time `json:"retiredKeyRevocation,omitempty"`
+
+	// AccessCredentials are the objects users backing the access credentials.
+	AccessCredentials []AccessCredentialsObservation `json:"accessCredentials,omitempty"`
+}
+
+// AccessCredentialsObservation is the observed state of a set of access
+// credentials.
+type AccessCredentialsObservation struct {
+	Name         string `json:"name"`
+	ObjectUserID string `json:"objectUserId"`
 }
 
 // S3BucketStatus defines the observed state of S3Bucket
@@ -62,6 +383,9 @@ type S3BucketStatus struct {
 // S3Bucket is the Schema for the s3buckets API
 // +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.sizeBytes"
+// +kubebuilder:printcolumn:name="OBJECTS",type="integer",JSONPath=".status.atProvider.objectCount"
+// +kubebuilder:printcolumn:name="MEASURED",type="date",JSONPath=".status.atProvider.lastMeasured"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
@@ -87,6 +411,11 @@ type S3BucketList struct {
 type S3BucketClassSpecTemplate struct {
 	runtimev1alpha1.ClassSpecTemplate `json:",inline"`
 	ForProvider                       S3BucketParameters `json:"forProvider,omitempty"`
+
+	// ConnectionSecretFormats are published to the connection secrets of
+	// S3Buckets using this class.
+	// +optional
+	ConnectionSecretFormats []ConnectionSecretFormat `json:"connectionSecretFormats,omitempty"`
 }
 
 // +kubebuilder:object:root=true
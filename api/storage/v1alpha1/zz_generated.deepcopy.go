@@ -21,9 +21,329 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCredentials) DeepCopyInto(out *AccessCredentials) {
+	*out = *in
+	out.WriteConnectionSecretToReference = in.WriteConnectionSecretToReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCredentials.
+func (in *AccessCredentials) DeepCopy() *AccessCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessCredentialsObservation) DeepCopyInto(out *AccessCredentialsObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessCredentialsObservation.
+func (in *AccessCredentialsObservation) DeepCopy() *AccessCredentialsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessCredentialsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORSRule) DeepCopyInto(out *CORSRule) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedMethods != nil {
+		in, out := &in.AllowedMethods, &out.AllowedMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHeaders != nil {
+		in, out := &in.AllowedHeaders, &out.AllowedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSRule.
+func (in *CORSRule) DeepCopy() *CORSRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CORSRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotation) DeepCopyInto(out *KeyRotation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotation.
+func (in *KeyRotation) DeepCopy() *KeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleRule) DeepCopyInto(out *LifecycleRule) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpirationDays != nil {
+		in, out := &in.ExpirationDays, &out.ExpirationDays
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NoncurrentVersionExpirationDays != nil {
+		in, out := &in.NoncurrentVersionExpirationDays, &out.NoncurrentVersionExpirationDays
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AbortIncompleteMultipartUploadDays != nil {
+		in, out := &in.AbortIncompleteMultipartUploadDays, &out.AbortIncompleteMultipartUploadDays
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleRule.
+func (in *LifecycleRule) DeepCopy() *LifecycleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectsUser) DeepCopyInto(out *ObjectsUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectsUser.
+func (in *ObjectsUser) DeepCopy() *ObjectsUser {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectsUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectsUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectsUserList) DeepCopyInto(out *ObjectsUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ObjectsUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectsUserList.
+func (in *ObjectsUserList) DeepCopy() *ObjectsUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectsUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectsUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectsUserObservation) DeepCopyInto(out *ObjectsUserObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectsUserObservation.
+func (in *ObjectsUserObservation) DeepCopy() *ObjectsUserObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectsUserObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectsUserParameters) DeepCopyInto(out *ObjectsUserParameters) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectsUserParameters.
+func (in *ObjectsUserParameters) DeepCopy() *ObjectsUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectsUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectsUserSpec) DeepCopyInto(out *ObjectsUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectsUserSpec.
+func (in *ObjectsUserSpec) DeepCopy() *ObjectsUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectsUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectsUserStatus) DeepCopyInto(out *ObjectsUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectsUserStatus.
+func (in *ObjectsUserStatus) DeepCopy() *ObjectsUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectsUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRule) DeepCopyInto(out *RoutingRule) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(RoutingRuleCondition)
+		**out = **in
+	}
+	out.Redirect = in.Redirect
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRule.
+func (in *RoutingRule) DeepCopy() *RoutingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleCondition) DeepCopyInto(out *RoutingRuleCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleCondition.
+func (in *RoutingRuleCondition) DeepCopy() *RoutingRuleCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingRuleRedirect) DeepCopyInto(out *RoutingRuleRedirect) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingRuleRedirect.
+func (in *RoutingRuleRedirect) DeepCopy() *RoutingRuleRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingRuleRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3Bucket) DeepCopyInto(out *S3Bucket) {
 	*out = *in
@@ -114,6 +434,11 @@ func (in *S3BucketClassSpecTemplate) DeepCopyInto(out *S3BucketClassSpecTemplate
 	*out = *in
 	in.ClassSpecTemplate.DeepCopyInto(&out.ClassSpecTemplate)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ConnectionSecretFormats != nil {
+		in, out := &in.ConnectionSecretFormats, &out.ConnectionSecretFormats
+		*out = make([]ConnectionSecretFormat, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketClassSpecTemplate.
@@ -161,6 +486,23 @@ func (in *S3BucketList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3BucketObservation) DeepCopyInto(out *S3BucketObservation) {
 	*out = *in
+	if in.LastMeasured != nil {
+		in, out := &in.LastMeasured, &out.LastMeasured
+		*out = (*in).DeepCopy()
+	}
+	if in.LastKeyRotation != nil {
+		in, out := &in.LastKeyRotation, &out.LastKeyRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.RetiredKeyRevocation != nil {
+		in, out := &in.RetiredKeyRevocation, &out.RetiredKeyRevocation
+		*out = (*in).DeepCopy()
+	}
+	if in.AccessCredentials != nil {
+		in, out := &in.AccessCredentials, &out.AccessCredentials
+		*out = make([]AccessCredentialsObservation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketObservation.
@@ -192,6 +534,55 @@ func (in *S3BucketParameters) DeepCopyInto(out *S3BucketParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(string)
+		**out = **in
+	}
+	if in.LifecycleRules != nil {
+		in, out := &in.LifecycleRules, &out.LifecycleRules
+		*out = make([]LifecycleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CORSRules != nil {
+		in, out := &in.CORSRules, &out.CORSRules
+		*out = make([]CORSRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Website != nil {
+		in, out := &in.Website, &out.Website
+		*out = new(WebsiteConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageSampleLimit != nil {
+		in, out := &in.UsageSampleLimit, &out.UsageSampleLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ObjectsUserRef != nil {
+		in, out := &in.ObjectsUserRef, &out.ObjectsUserRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(KeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessCredentials != nil {
+		in, out := &in.AccessCredentials, &out.AccessCredentials
+		*out = make([]AccessCredentials, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketParameters.
@@ -209,6 +600,11 @@ func (in *S3BucketSpec) DeepCopyInto(out *S3BucketSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ConnectionSecretFormats != nil {
+		in, out := &in.ConnectionSecretFormats, &out.ConnectionSecretFormats
+		*out = make([]ConnectionSecretFormat, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketSpec.
@@ -225,7 +621,7 @@ func (in *S3BucketSpec) DeepCopy() *S3BucketSpec {
 func (in *S3BucketStatus) DeepCopyInto(out *S3BucketStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketStatus.
@@ -237,3 +633,25 @@ func (in *S3BucketStatus) DeepCopy() *S3BucketStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebsiteConfiguration) DeepCopyInto(out *WebsiteConfiguration) {
+	*out = *in
+	if in.RoutingRules != nil {
+		in, out := &in.RoutingRules, &out.RoutingRules
+		*out = make([]RoutingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebsiteConfiguration.
+func (in *WebsiteConfiguration) DeepCopy() *WebsiteConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WebsiteConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
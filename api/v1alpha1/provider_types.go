@@ -25,17 +25,65 @@ type ProviderSpec struct {
 	// A Secret containing credentials for a Favourite Cloud Service Account
 	// that will be used to authenticate to this Provider.
 	Secret runtimev1alpha1.SecretKeySelector `json:"credentialsSecretRef"`
+
+	// APIURL is the base URL of the Cloudscale API, e.g.
+	// "https://api.cloudscale.ch/". Defaults to the public Cloudscale API.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// ObjectStorageEndpointTemplate is the endpoint of the object storage
+	// with a %s placeholder for the region, e.g.
+	// "https://objects.%s.cloudscale.ch". Defaults to the public Cloudscale
+	// object storage.
+	// +optional
+	ObjectStorageEndpointTemplate string `json:"objectStorageEndpointTemplate,omitempty"`
+
+	// ObjectStorageWebsiteEndpointTemplate is the endpoint of a bucket served
+	// as static website with %s placeholders for the bucket name and region,
+	// e.g. "https://%s.objects-website.%s.cloudscale.ch". Defaults to the
+	// public Cloudscale object storage.
+	// +optional
+	ObjectStorageWebsiteEndpointTemplate string `json:"objectStorageWebsiteEndpointTemplate,omitempty"`
+}
+
+// TokenScope is the scope of a Cloudscale API token.
+type TokenScope string
+
+// The scopes of a Cloudscale API token.
+const (
+	TokenScopeReadOnly  TokenScope = "read-only"
+	TokenScopeReadWrite TokenScope = "read-write"
+	TokenScopeUnknown   TokenScope = "unknown"
+)
+
+// ProviderStatus defines the observed state of Provider. The token of the
+// Provider is verified periodically.
+type ProviderStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// LastVerified is the time the token was last verified successfully.
+	LastVerified *metav1.Time `json:"lastVerified,omitempty"`
+
+	// TokenScope is the scope of the token, either read-only, read-write or
+	// unknown if the scope couldn't be determined.
+	TokenScope TokenScope `json:"tokenScope,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // Provider is the Schema for the providers API
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SCOPE",type="string",JSONPath=".status.tokenScope"
+// +kubebuilder:printcolumn:name="VERIFIED",type="date",JSONPath=".status.lastVerified"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
 type Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ProviderSpec `json:"spec,omitempty"`
+	Spec   ProviderSpec   `json:"spec,omitempty"`
+	Status ProviderStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
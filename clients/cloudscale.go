@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clients contains helpers shared by the Cloudscale clients.
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	cloudscalev1alpha1 "github.com/vshn/stack-cloudscale/api/v1alpha1"
+)
+
+// DefaultObjectStorageEndpointTemplate is the endpoint of the Cloudscale
+// object storage without the region
+const DefaultObjectStorageEndpointTemplate = "https://objects.%s.cloudscale.ch"
+
+// DefaultObjectStorageWebsiteEndpointTemplate is the endpoint of a bucket
+// served as static website by the Cloudscale object storage, without the
+// bucket name and region
+const DefaultObjectStorageWebsiteEndpointTemplate = "https://%s.objects-website.%s.cloudscale.ch"
+
+// ProviderConfig is the configuration of the Cloudscale clients read from a
+// Provider.
+type ProviderConfig struct {
+	// Token is the Cloudscale API token.
+	Token string
+
+	// APIURL is the base URL of the Cloudscale API. The default of the SDK is
+	// used if nil.
+	APIURL *url.URL
+
+	// ObjectStorageEndpointTemplate is the endpoint of the object storage
+	// with a %s placeholder for the region.
+	ObjectStorageEndpointTemplate string
+
+	// ObjectStorageWebsiteEndpointTemplate is the endpoint of a bucket served
+	// as static website with %s placeholders for the bucket name and region.
+	ObjectStorageWebsiteEndpointTemplate string
+}
+
+// ObjectStorageEndpoint returns the endpoint of the object storage in the
+// region.
+func (c *ProviderConfig) ObjectStorageEndpoint(region string) string {
+	return fmt.Sprintf(c.ObjectStorageEndpointTemplate, region)
+}
+
+// ObjectStorageWebsiteEndpoint returns the endpoint of the bucket in the
+// region served as static website.
+func (c *ProviderConfig) ObjectStorageWebsiteEndpoint(bucketName, region string) string {
+	return fmt.Sprintf(c.ObjectStorageWebsiteEndpointTemplate, bucketName, region)
+}
+
+// GetProviderConfig returns the configuration of the referenced Provider.
+func GetProviderConfig(ctx context.Context, kube client.Client, providerRef *corev1.ObjectReference) (*ProviderConfig, error) {
+	// Get the Provider referenced by the managed resource.
+	p := &cloudscalev1alpha1.Provider{}
+	if err := kube.Get(ctx, meta.NamespacedNameOf(providerRef), p); err != nil {
+		return nil, errors.Wrap(err, "cannot get Provider")
+	}
+	return GetConfig(ctx, kube, p)
+}
+
+// GetConfig returns the configuration of the Provider, with the Cloudscale API
+// token stored in the Secret of the Provider.
+func GetConfig(ctx context.Context, kube client.Client, p *cloudscalev1alpha1.Provider) (*ProviderConfig, error) {
+	cfg := &ProviderConfig{
+		ObjectStorageEndpointTemplate:        DefaultObjectStorageEndpointTemplate,
+		ObjectStorageWebsiteEndpointTemplate: DefaultObjectStorageWebsiteEndpointTemplate,
+	}
+	if p.Spec.APIURL != "" {
+		u, err := url.Parse(p.Spec.APIURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse Provider API URL")
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, errors.Errorf("Provider API URL %q must be absolute", p.Spec.APIURL)
+		}
+		// The paths of the requests are resolved relative to the API URL,
+		// which would drop its last path segment without a trailing slash.
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		cfg.APIURL = u
+	}
+	if t := p.Spec.ObjectStorageEndpointTemplate; t != "" {
+		if strings.Count(t, "%s") != 1 {
+			return nil, errors.Errorf("Provider object storage endpoint template %q must contain exactly one %%s", t)
+		}
+		cfg.ObjectStorageEndpointTemplate = t
+	}
+	if t := p.Spec.ObjectStorageWebsiteEndpointTemplate; t != "" {
+		if strings.Count(t, "%s") != 2 {
+			return nil, errors.Errorf("Provider object storage website endpoint template %q must contain exactly two %%s", t)
+		}
+		cfg.ObjectStorageWebsiteEndpointTemplate = t
+	}
+
+	// Get the Secret referenced by the Provider.
+	s := &corev1.Secret{}
+	n := types.NamespacedName{Namespace: p.Spec.Secret.Namespace, Name: p.Spec.Secret.Name}
+	if err := kube.Get(ctx, n, s); err != nil {
+		return nil, errors.Wrapf(err, "cannot get Provider secret %s", n)
+	}
+	cfg.Token = string(s.Data[p.Spec.Secret.Key])
+	return cfg, nil
+}
+
+// NewCloudscaleClient creates a client of the Cloudscale API with the token
+// and API URL of the configuration. The shared HTTP client of the token is
+// used if httpClient is nil.
+func NewCloudscaleClient(cfg *ProviderConfig, httpClient *http.Client) *cloudscale.Client {
+	if httpClient == nil {
+		httpClient = NewHTTPClient(cfg.Token)
+	}
+	c := cloudscale.NewClient(httpClient)
+	c.AuthToken = cfg.Token
+	if cfg.APIURL != nil {
+		c.BaseURL = cfg.APIURL
+	}
+	return c
+}
+
+// Do sends a request with the optional body to the Cloudscale API and decodes
+// the response into v, if not nil. It is used for the APIs the SDK doesn't
+// support yet.
+func Do(ctx context.Context, c *cloudscale.Client, method, path string, body, v interface{}) error {
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, v)
+}
+
+// IsErrorNotFound returns true if the Cloudscale API responded with not found
+func IsErrorNotFound(err error) bool {
+	if errResp, ok := err.(*cloudscale.ErrorResponse); ok {
+		return errResp.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// ErrorNotFound returns the error of the Cloudscale API for a resource which
+// doesn't exist.
+func ErrorNotFound(message string) error {
+	return &cloudscale.ErrorResponse{
+		StatusCode: http.StatusNotFound,
+		Message: map[string]string{
+			"Error": message,
+		},
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compute contains the client of the Cloudscale compute resources.
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+// Service defines Compute Client operations
+type Service interface {
+	CreateServer(ctx context.Context, req *ServerRequest) (*cloudscale.Server, error)
+	GetServer(ctx context.Context, uuid, name string) (*cloudscale.Server, error)
+	UpdateServer(ctx context.Context, uuid string, req *cloudscale.ServerUpdateRequest) error
+	DeleteServer(ctx context.Context, uuid string) error
+	CreateVolume(ctx context.Context, req *VolumeRequest) (*cloudscale.Volume, error)
+	GetVolume(ctx context.Context, uuid, name string) (*cloudscale.Volume, error)
+	UpdateVolume(ctx context.Context, uuid string, req *cloudscale.VolumeRequest) error
+	DeleteVolume(ctx context.Context, uuid string) error
+	CreateVolumeSnapshot(ctx context.Context, req *VolumeSnapshotRequest) (*VolumeSnapshot, error)
+	GetVolumeSnapshot(ctx context.Context, uuid, name string) (*VolumeSnapshot, error)
+	UpdateVolumeSnapshot(ctx context.Context, uuid string, req *VolumeSnapshotRequest) error
+	DeleteVolumeSnapshot(ctx context.Context, uuid string) error
+	CreateServerGroup(ctx context.Context, req *cloudscale.ServerGroupRequest) (*cloudscale.ServerGroup, error)
+	GetServerGroup(ctx context.Context, uuid, name string) (*cloudscale.ServerGroup, error)
+	UpdateServerGroup(ctx context.Context, uuid string, req *ServerGroupUpdateRequest) error
+	DeleteServerGroup(ctx context.Context, uuid string) error
+	ImportCustomImage(ctx context.Context, req *CustomImageImportRequest) (*CustomImageImport, error)
+	GetCustomImageImport(ctx context.Context, uuid string) (*CustomImageImport, error)
+	GetCustomImage(ctx context.Context, uuid, name string) (*CustomImage, error)
+	UpdateCustomImage(ctx context.Context, uuid string, req *CustomImageUpdateRequest) error
+	DeleteCustomImage(ctx context.Context, uuid string) error
+}
+
+// Client implements the Compute Client
+type Client struct {
+	cloudscaleClient *cloudscale.Client
+}
+
+// NewClient creates a new Compute Client with the provided Provider configuration
+func NewClient(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) Service {
+	return &Client{
+		cloudscaleClient: clients.NewCloudscaleClient(config, httpClient),
+	}
+}
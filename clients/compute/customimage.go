@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const (
+	customImageBasePath       = "v1/custom-images"
+	customImageImportBasePath = "v1/custom-images/import"
+)
+
+// Statuses of a custom image import.
+const (
+	ImportStarted    = "started"
+	ImportInProgress = "in_progress"
+	ImportSuccess    = "success"
+	ImportFailed     = "failed"
+)
+
+// CustomImage is an image imported into Cloudscale. The SDK doesn't support
+// custom images yet.
+type CustomImage struct {
+	HREF             string            `json:"href"`
+	UUID             string            `json:"uuid"`
+	Name             string            `json:"name"`
+	Slug             string            `json:"slug"`
+	SizeGB           int               `json:"size_gb"`
+	UserDataHandling string            `json:"user_data_handling"`
+	FirmwareType     string            `json:"firmware_type"`
+	Zones            []cloudscale.Zone `json:"zones"`
+}
+
+// CustomImageStub is the reference to a custom image
+type CustomImageStub struct {
+	HREF string `json:"href"`
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// CustomImageImport is the import of a custom image from a URL
+type CustomImageImport struct {
+	HREF         string          `json:"href"`
+	UUID         string          `json:"uuid"`
+	URL          string          `json:"url"`
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"error_message"`
+	CustomImage  CustomImageStub `json:"custom_image"`
+}
+
+// CustomImageImportRequest imports a custom image from a URL
+type CustomImageImportRequest struct {
+	URL              string   `json:"url"`
+	Name             string   `json:"name"`
+	Slug             string   `json:"slug,omitempty"`
+	UserDataHandling string   `json:"user_data_handling,omitempty"`
+	FirmwareType     string   `json:"firmware_type,omitempty"`
+	Zones            []string `json:"zones,omitempty"`
+	SourceFormat     string   `json:"source_format,omitempty"`
+}
+
+// CustomImageUpdateRequest updates the name, slug and user data handling of
+// a custom image
+type CustomImageUpdateRequest struct {
+	Name             string `json:"name,omitempty"`
+	Slug             string `json:"slug,omitempty"`
+	UserDataHandling string `json:"user_data_handling,omitempty"`
+}
+
+// ImportCustomImage starts the import of a custom image
+func (c *Client) ImportCustomImage(ctx context.Context, req *CustomImageImportRequest) (*CustomImageImport, error) {
+	imp := &CustomImageImport{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, customImageImportBasePath, req, imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// GetCustomImageImport returns the import of a custom image
+func (c *Client) GetCustomImageImport(ctx context.Context, uuid string) (*CustomImageImport, error) {
+	imp := &CustomImageImport{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", customImageImportBasePath, uuid), nil, imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// GetCustomImage returns a custom image. The image is looked up by its name
+// if the UUID is empty.
+func (c *Client) GetCustomImage(ctx context.Context, uuid, name string) (*CustomImage, error) {
+	if uuid != "" {
+		image := &CustomImage{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", customImageBasePath, uuid), nil, image); err != nil {
+			return nil, err
+		}
+		return image, nil
+	}
+	images := []CustomImage{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, customImageBasePath, nil, &images); err != nil {
+		return nil, err
+	}
+	for _, i := range images {
+		if i.Name == name {
+			return &i, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Custom image not found")
+}
+
+// UpdateCustomImage updates a custom image
+func (c *Client) UpdateCustomImage(ctx context.Context, uuid string, req *CustomImageUpdateRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", customImageBasePath, uuid), req, nil)
+}
+
+// DeleteCustomImage deletes a custom image
+func (c *Client) DeleteCustomImage(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", customImageBasePath, uuid), nil, nil)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const serverBasePath = "v1/servers"
+
+// ServerRequest extends the server request of the SDK with the interfaces of
+// the server, which the SDK doesn't support.
+type ServerRequest struct {
+	cloudscale.ServerRequest
+	Interfaces []InterfaceRequest `json:"interfaces,omitempty"`
+}
+
+// InterfaceRequest attaches a server to the public network or a private
+// network.
+type InterfaceRequest struct {
+	Network   string           `json:"network"`
+	Addresses []AddressRequest `json:"addresses,omitempty"`
+}
+
+// AddressRequest is the address of an interface in a subnet.
+type AddressRequest struct {
+	Subnet  string `json:"subnet"`
+	Address string `json:"address,omitempty"`
+}
+
+// CreateServer creates a server
+func (c *Client) CreateServer(ctx context.Context, req *ServerRequest) (*cloudscale.Server, error) {
+	server := &cloudscale.Server{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, serverBasePath, req, server); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// GetServer returns a server. The server is looked up by its name if the
+// UUID is empty.
+func (c *Client) GetServer(ctx context.Context, uuid, name string) (*cloudscale.Server, error) {
+	if uuid != "" {
+		return c.cloudscaleClient.Servers.Get(ctx, uuid)
+	}
+	servers, err := c.cloudscaleClient.Servers.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range servers {
+		if s.Name == name {
+			return &s, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Server not found")
+}
+
+// UpdateServer updates the name, flavor or status of a server
+func (c *Client) UpdateServer(ctx context.Context, uuid string, req *cloudscale.ServerUpdateRequest) error {
+	return c.cloudscaleClient.Servers.Update(ctx, uuid, req)
+}
+
+// DeleteServer deletes a server and its root volume
+func (c *Client) DeleteServer(ctx context.Context, uuid string) error {
+	return c.cloudscaleClient.Servers.Delete(ctx, uuid)
+}
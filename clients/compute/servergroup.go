@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const serverGroupBasePath = "v1/server-groups"
+
+// ServerGroupUpdateRequest renames a server group, which the SDK doesn't
+// support.
+type ServerGroupUpdateRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateServerGroup creates a server group
+func (c *Client) CreateServerGroup(ctx context.Context, req *cloudscale.ServerGroupRequest) (*cloudscale.ServerGroup, error) {
+	return c.cloudscaleClient.ServerGroups.Create(ctx, req)
+}
+
+// GetServerGroup returns a server group. The server group is looked up by its
+// name if the UUID is empty.
+func (c *Client) GetServerGroup(ctx context.Context, uuid, name string) (*cloudscale.ServerGroup, error) {
+	if uuid != "" {
+		return c.cloudscaleClient.ServerGroups.Get(ctx, uuid)
+	}
+	groups, err := c.cloudscaleClient.ServerGroups.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if g.Name == name {
+			return &g, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Server group not found")
+}
+
+// UpdateServerGroup renames a server group
+func (c *Client) UpdateServerGroup(ctx context.Context, uuid string, req *ServerGroupUpdateRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", serverGroupBasePath, uuid), req, nil)
+}
+
+// DeleteServerGroup deletes a server group
+func (c *Client) DeleteServerGroup(ctx context.Context, uuid string) error {
+	return c.cloudscaleClient.ServerGroups.Delete(ctx, uuid)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+// VolumeRequest creates a volume. The SDK doesn't support restoring a volume
+// from a snapshot yet.
+type VolumeRequest struct {
+	cloudscale.VolumeRequest
+	VolumeSnapshotUUID string `json:"volume_snapshot_uuid,omitempty"`
+}
+
+// CreateVolume creates a volume, restored from a snapshot if its UUID is set
+func (c *Client) CreateVolume(ctx context.Context, req *VolumeRequest) (*cloudscale.Volume, error) {
+	volume := &cloudscale.Volume{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, "v1/volumes", req, volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// GetVolume returns a volume. The volume is looked up by its name if the
+// UUID is empty.
+func (c *Client) GetVolume(ctx context.Context, uuid, name string) (*cloudscale.Volume, error) {
+	if uuid != "" {
+		return c.cloudscaleClient.Volumes.Get(ctx, uuid)
+	}
+	volumes, err := c.cloudscaleClient.Volumes.List(ctx, &cloudscale.ListVolumeParams{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		if v.Name == name {
+			return &v, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Volume not found")
+}
+
+// UpdateVolume updates the name, size or attached servers of a volume. The
+// size of an attached volume is grown online.
+func (c *Client) UpdateVolume(ctx context.Context, uuid string, req *cloudscale.VolumeRequest) error {
+	return c.cloudscaleClient.Volumes.Update(ctx, uuid, req)
+}
+
+// DeleteVolume deletes a volume
+func (c *Client) DeleteVolume(ctx context.Context, uuid string) error {
+	return c.cloudscaleClient.Volumes.Delete(ctx, uuid)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const volumeSnapshotBasePath = "v1/volume-snapshots"
+
+// VolumeSnapshotStatusAvailable is the status of a snapshot which was taken
+// successfully and can be restored.
+const VolumeSnapshotStatusAvailable = "available"
+
+// VolumeSnapshot is a point-in-time snapshot of a volume
+type VolumeSnapshot struct {
+	cloudscale.ZonalResource
+	HREF         string     `json:"href"`
+	UUID         string     `json:"uuid"`
+	Name         string     `json:"name"`
+	SizeGB       int        `json:"size_gb"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Status       string     `json:"status"`
+	SourceVolume VolumeStub `json:"source_volume"`
+}
+
+// VolumeStub is the reference to a volume
+type VolumeStub struct {
+	HREF string `json:"href"`
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// VolumeSnapshotRequest creates or updates a snapshot. Only the name can be
+// updated.
+type VolumeSnapshotRequest struct {
+	Name         string `json:"name,omitempty"`
+	SourceVolume string `json:"source_volume,omitempty"`
+}
+
+// CreateVolumeSnapshot takes a snapshot of a volume
+func (c *Client) CreateVolumeSnapshot(ctx context.Context, req *VolumeSnapshotRequest) (*VolumeSnapshot, error) {
+	snapshot := &VolumeSnapshot{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, volumeSnapshotBasePath, req, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetVolumeSnapshot returns a snapshot. The snapshot is looked up by its name
+// if the UUID is empty.
+func (c *Client) GetVolumeSnapshot(ctx context.Context, uuid, name string) (*VolumeSnapshot, error) {
+	if uuid != "" {
+		snapshot := &VolumeSnapshot{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", volumeSnapshotBasePath, uuid), nil, snapshot); err != nil {
+			return nil, err
+		}
+		return snapshot, nil
+	}
+	snapshots := []VolumeSnapshot{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, volumeSnapshotBasePath, nil, &snapshots); err != nil {
+		return nil, err
+	}
+	for _, s := range snapshots {
+		if s.Name == name {
+			return &s, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Volume snapshot not found")
+}
+
+// UpdateVolumeSnapshot updates the name of a snapshot
+func (c *Client) UpdateVolumeSnapshot(ctx context.Context, uuid string, req *VolumeSnapshotRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", volumeSnapshotBasePath, uuid), req, nil)
+}
+
+// DeleteVolumeSnapshot deletes a snapshot
+func (c *Client) DeleteVolumeSnapshot(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", volumeSnapshotBasePath, uuid), nil, nil)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const floatingIPBasePath = "v1/floating-ips"
+
+// FloatingIPRequest creates a floating IP. Unlike the request of the SDK, it
+// supports global floating IPs and floating IPs without a server.
+type FloatingIPRequest struct {
+	cloudscale.RegionalResourceRequest
+	IPVersion      int    `json:"ip_version"`
+	Server         string `json:"server,omitempty"`
+	PrefixLength   int    `json:"prefix_length,omitempty"`
+	ReversePointer string `json:"reverse_ptr,omitempty"`
+	Type           string `json:"type,omitempty"`
+}
+
+// FloatingIPUpdateRequest assigns a floating IP to a server, or unassigns it
+// if the server is nil, and updates its reverse pointer.
+type FloatingIPUpdateRequest struct {
+	Server         *string `json:"server"`
+	ReversePointer string  `json:"reverse_ptr,omitempty"`
+}
+
+// CreateFloatingIP creates a floating IP
+func (c *Client) CreateFloatingIP(ctx context.Context, req *FloatingIPRequest) (*cloudscale.FloatingIP, error) {
+	floatingIP := &cloudscale.FloatingIP{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, floatingIPBasePath, req, floatingIP); err != nil {
+		return nil, err
+	}
+	return floatingIP, nil
+}
+
+// GetFloatingIP returns the floating IP with the supplied address
+func (c *Client) GetFloatingIP(ctx context.Context, ip string) (*cloudscale.FloatingIP, error) {
+	return c.cloudscaleClient.FloatingIPs.Get(ctx, ip)
+}
+
+// UpdateFloatingIP reassigns a floating IP and updates its reverse pointer
+func (c *Client) UpdateFloatingIP(ctx context.Context, ip string, req *FloatingIPUpdateRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", floatingIPBasePath, ip), req, nil)
+}
+
+// DeleteFloatingIP deletes a floating IP
+func (c *Client) DeleteFloatingIP(ctx context.Context, ip string) error {
+	return c.cloudscaleClient.FloatingIPs.Delete(ctx, ip)
+}
@@ -0,0 +1,417 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const (
+	loadBalancerBasePath              = "v1/load-balancers"
+	loadBalancerPoolBasePath          = "v1/load-balancers/pools"
+	loadBalancerListenerBasePath      = "v1/load-balancers/listeners"
+	loadBalancerHealthMonitorBasePath = "v1/load-balancers/health-monitors"
+)
+
+// LoadBalancerStatusRunning is the status of a load balancer, pool, member,
+// listener or health monitor which is provisioned and not being changed.
+const LoadBalancerStatusRunning = "running"
+
+// LoadBalancer is a load balancer
+type LoadBalancer struct {
+	cloudscale.ZonalResource
+	HREF   string `json:"href"`
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Flavor struct {
+		Slug string `json:"slug"`
+	} `json:"flavor"`
+	Status          string       `json:"status"`
+	OperatingStatus string       `json:"operating_status"`
+	VIPAddresses    []VIPAddress `json:"vip_addresses"`
+}
+
+// VIPAddress is a virtual IP of a load balancer
+type VIPAddress struct {
+	Version int        `json:"version"`
+	Address string     `json:"address"`
+	Subnet  SubnetStub `json:"subnet"`
+}
+
+// LoadBalancerStub is the reference to a load balancer
+type LoadBalancerStub struct {
+	HREF string `json:"href"`
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// LoadBalancerRequest creates or updates a load balancer. Only the name can be
+// updated.
+type LoadBalancerRequest struct {
+	cloudscale.ZonalResourceRequest
+	Name         string              `json:"name,omitempty"`
+	Flavor       string              `json:"flavor,omitempty"`
+	VIPAddresses []VIPAddressRequest `json:"vip_addresses,omitempty"`
+}
+
+// VIPAddressRequest requests a virtual IP in a subnet
+type VIPAddressRequest struct {
+	Subnet  string `json:"subnet"`
+	Address string `json:"address,omitempty"`
+}
+
+// LoadBalancerPool is a pool of members of a load balancer
+type LoadBalancerPool struct {
+	HREF            string           `json:"href"`
+	UUID            string           `json:"uuid"`
+	Name            string           `json:"name"`
+	LoadBalancer    LoadBalancerStub `json:"load_balancer"`
+	Algorithm       string           `json:"algorithm"`
+	Protocol        string           `json:"protocol"`
+	Status          string           `json:"status"`
+	OperatingStatus string           `json:"operating_status"`
+}
+
+// LoadBalancerPoolStub is the reference to a pool
+type LoadBalancerPoolStub struct {
+	HREF string `json:"href"`
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// LoadBalancerPoolRequest creates or updates a pool. Only the name can be
+// updated.
+type LoadBalancerPoolRequest struct {
+	Name         string `json:"name,omitempty"`
+	LoadBalancer string `json:"load_balancer,omitempty"`
+	Algorithm    string `json:"algorithm,omitempty"`
+	Protocol     string `json:"protocol,omitempty"`
+}
+
+// LoadBalancerPoolMember is a backend server of a pool
+type LoadBalancerPoolMember struct {
+	HREF          string               `json:"href"`
+	UUID          string               `json:"uuid"`
+	Name          string               `json:"name"`
+	Enabled       bool                 `json:"enabled"`
+	Pool          LoadBalancerPoolStub `json:"pool"`
+	LoadBalancer  LoadBalancerStub     `json:"load_balancer"`
+	ProtocolPort  int                  `json:"protocol_port"`
+	MonitorPort   int                  `json:"monitor_port"`
+	Address       string               `json:"address"`
+	Subnet        SubnetStub           `json:"subnet"`
+	Status        string               `json:"status"`
+	MonitorStatus string               `json:"monitor_status"`
+}
+
+// LoadBalancerPoolMemberRequest creates or updates a pool member. The address
+// and subnet can't be updated.
+type LoadBalancerPoolMemberRequest struct {
+	Name         string `json:"name,omitempty"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	ProtocolPort int    `json:"protocol_port,omitempty"`
+	MonitorPort  int    `json:"monitor_port,omitempty"`
+	Address      string `json:"address,omitempty"`
+	Subnet       string `json:"subnet,omitempty"`
+}
+
+// LoadBalancerListener accepts connections and forwards them to a pool
+type LoadBalancerListener struct {
+	HREF                   string               `json:"href"`
+	UUID                   string               `json:"uuid"`
+	Name                   string               `json:"name"`
+	Pool                   LoadBalancerPoolStub `json:"pool"`
+	LoadBalancer           LoadBalancerStub     `json:"load_balancer"`
+	Protocol               string               `json:"protocol"`
+	ProtocolPort           int                  `json:"protocol_port"`
+	AllowedCIDRs           []string             `json:"allowed_cidrs"`
+	TimeoutClientDataMS    int                  `json:"timeout_client_data_ms"`
+	TimeoutMemberConnectMS int                  `json:"timeout_member_connect_ms"`
+	TimeoutMemberDataMS    int                  `json:"timeout_member_data_ms"`
+	Status                 string               `json:"status"`
+	OperatingStatus        string               `json:"operating_status"`
+}
+
+// LoadBalancerListenerRequest creates or updates a listener. The pool and
+// protocol can't be updated.
+type LoadBalancerListenerRequest struct {
+	Name                   string    `json:"name,omitempty"`
+	Pool                   string    `json:"pool,omitempty"`
+	Protocol               string    `json:"protocol,omitempty"`
+	ProtocolPort           int       `json:"protocol_port,omitempty"`
+	AllowedCIDRs           *[]string `json:"allowed_cidrs,omitempty"`
+	TimeoutClientDataMS    int       `json:"timeout_client_data_ms,omitempty"`
+	TimeoutMemberConnectMS int       `json:"timeout_member_connect_ms,omitempty"`
+	TimeoutMemberDataMS    int       `json:"timeout_member_data_ms,omitempty"`
+}
+
+// LoadBalancerHealthMonitor checks the members of a pool
+type LoadBalancerHealthMonitor struct {
+	HREF            string                         `json:"href"`
+	UUID            string                         `json:"uuid"`
+	Pool            LoadBalancerPoolStub           `json:"pool"`
+	LoadBalancer    LoadBalancerStub               `json:"load_balancer"`
+	DelayS          int                            `json:"delay_s"`
+	TimeoutS        int                            `json:"timeout_s"`
+	UpThreshold     int                            `json:"up_threshold"`
+	DownThreshold   int                            `json:"down_threshold"`
+	Type            string                         `json:"type"`
+	HTTP            *LoadBalancerHealthMonitorHTTP `json:"http"`
+	Status          string                         `json:"status"`
+	OperatingStatus string                         `json:"operating_status"`
+}
+
+// LoadBalancerHealthMonitorHTTP configures an HTTP health check
+type LoadBalancerHealthMonitorHTTP struct {
+	ExpectedCodes []string `json:"expected_codes,omitempty"`
+	Method        string   `json:"method,omitempty"`
+	URLPath       string   `json:"url_path,omitempty"`
+	Version       string   `json:"version,omitempty"`
+	Host          *string  `json:"host,omitempty"`
+}
+
+// LoadBalancerHealthMonitorRequest creates or updates a health monitor. The
+// pool and type can't be updated.
+type LoadBalancerHealthMonitorRequest struct {
+	Pool          string                         `json:"pool,omitempty"`
+	DelayS        int                            `json:"delay_s,omitempty"`
+	TimeoutS      int                            `json:"timeout_s,omitempty"`
+	UpThreshold   int                            `json:"up_threshold,omitempty"`
+	DownThreshold int                            `json:"down_threshold,omitempty"`
+	Type          string                         `json:"type,omitempty"`
+	HTTP          *LoadBalancerHealthMonitorHTTP `json:"http,omitempty"`
+}
+
+// CreateLoadBalancer creates a load balancer
+func (c *Client) CreateLoadBalancer(ctx context.Context, req *LoadBalancerRequest) (*LoadBalancer, error) {
+	lb := &LoadBalancer{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, loadBalancerBasePath, req, lb); err != nil {
+		return nil, err
+	}
+	return lb, nil
+}
+
+// GetLoadBalancer returns a load balancer. The load balancer is looked up by
+// its name if the UUID is empty.
+func (c *Client) GetLoadBalancer(ctx context.Context, uuid, name string) (*LoadBalancer, error) {
+	if uuid != "" {
+		lb := &LoadBalancer{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", loadBalancerBasePath, uuid), nil, lb); err != nil {
+			return nil, err
+		}
+		return lb, nil
+	}
+	lbs := []LoadBalancer{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, loadBalancerBasePath, nil, &lbs); err != nil {
+		return nil, err
+	}
+	for _, lb := range lbs {
+		if lb.Name == name {
+			return &lb, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Load balancer not found")
+}
+
+// UpdateLoadBalancer updates the name of a load balancer
+func (c *Client) UpdateLoadBalancer(ctx context.Context, uuid string, req *LoadBalancerRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", loadBalancerBasePath, uuid), req, nil)
+}
+
+// DeleteLoadBalancer deletes a load balancer together with its pools,
+// listeners and health monitors
+func (c *Client) DeleteLoadBalancer(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", loadBalancerBasePath, uuid), nil, nil)
+}
+
+// CreateLoadBalancerPool creates a pool
+func (c *Client) CreateLoadBalancerPool(ctx context.Context, req *LoadBalancerPoolRequest) (*LoadBalancerPool, error) {
+	pool := &LoadBalancerPool{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, loadBalancerPoolBasePath, req, pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// GetLoadBalancerPool returns a pool. The pool is looked up by its load
+// balancer and name if the UUID is empty.
+func (c *Client) GetLoadBalancerPool(ctx context.Context, uuid, loadBalancerUUID, name string) (*LoadBalancerPool, error) {
+	if uuid != "" {
+		pool := &LoadBalancerPool{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", loadBalancerPoolBasePath, uuid), nil, pool); err != nil {
+			return nil, err
+		}
+		return pool, nil
+	}
+	pools := []LoadBalancerPool{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, loadBalancerPoolBasePath, nil, &pools); err != nil {
+		return nil, err
+	}
+	for _, p := range pools {
+		if p.LoadBalancer.UUID == loadBalancerUUID && p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Load balancer pool not found")
+}
+
+// UpdateLoadBalancerPool updates the name of a pool
+func (c *Client) UpdateLoadBalancerPool(ctx context.Context, uuid string, req *LoadBalancerPoolRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", loadBalancerPoolBasePath, uuid), req, nil)
+}
+
+// DeleteLoadBalancerPool deletes a pool together with its members and health
+// monitor
+func (c *Client) DeleteLoadBalancerPool(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", loadBalancerPoolBasePath, uuid), nil, nil)
+}
+
+// CreateLoadBalancerPoolMember adds a member to a pool
+func (c *Client) CreateLoadBalancerPoolMember(ctx context.Context, poolUUID string, req *LoadBalancerPoolMemberRequest) (*LoadBalancerPoolMember, error) {
+	member := &LoadBalancerPoolMember{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, memberBasePath(poolUUID), req, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// GetLoadBalancerPoolMember returns a member of a pool. The member is looked
+// up by its name if the UUID is empty.
+func (c *Client) GetLoadBalancerPoolMember(ctx context.Context, poolUUID, uuid, name string) (*LoadBalancerPoolMember, error) {
+	if uuid != "" {
+		member := &LoadBalancerPoolMember{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", memberBasePath(poolUUID), uuid), nil, member); err != nil {
+			return nil, err
+		}
+		return member, nil
+	}
+	members := []LoadBalancerPoolMember{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, memberBasePath(poolUUID), nil, &members); err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.Name == name {
+			return &m, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Load balancer pool member not found")
+}
+
+// UpdateLoadBalancerPoolMember updates the name, ports and enabled state of a
+// member
+func (c *Client) UpdateLoadBalancerPoolMember(ctx context.Context, poolUUID, uuid string, req *LoadBalancerPoolMemberRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", memberBasePath(poolUUID), uuid), req, nil)
+}
+
+// DeleteLoadBalancerPoolMember removes a member from a pool
+func (c *Client) DeleteLoadBalancerPoolMember(ctx context.Context, poolUUID, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", memberBasePath(poolUUID), uuid), nil, nil)
+}
+
+// CreateLoadBalancerListener creates a listener
+func (c *Client) CreateLoadBalancerListener(ctx context.Context, req *LoadBalancerListenerRequest) (*LoadBalancerListener, error) {
+	listener := &LoadBalancerListener{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, loadBalancerListenerBasePath, req, listener); err != nil {
+		return nil, err
+	}
+	return listener, nil
+}
+
+// GetLoadBalancerListener returns a listener. The listener is looked up by its
+// pool and name if the UUID is empty.
+func (c *Client) GetLoadBalancerListener(ctx context.Context, uuid, poolUUID, name string) (*LoadBalancerListener, error) {
+	if uuid != "" {
+		listener := &LoadBalancerListener{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", loadBalancerListenerBasePath, uuid), nil, listener); err != nil {
+			return nil, err
+		}
+		return listener, nil
+	}
+	listeners := []LoadBalancerListener{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, loadBalancerListenerBasePath, nil, &listeners); err != nil {
+		return nil, err
+	}
+	for _, l := range listeners {
+		if l.Pool.UUID == poolUUID && l.Name == name {
+			return &l, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Load balancer listener not found")
+}
+
+// UpdateLoadBalancerListener updates the name, port, allowed CIDRs and
+// timeouts of a listener
+func (c *Client) UpdateLoadBalancerListener(ctx context.Context, uuid string, req *LoadBalancerListenerRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", loadBalancerListenerBasePath, uuid), req, nil)
+}
+
+// DeleteLoadBalancerListener deletes a listener
+func (c *Client) DeleteLoadBalancerListener(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", loadBalancerListenerBasePath, uuid), nil, nil)
+}
+
+// CreateLoadBalancerHealthMonitor creates a health monitor
+func (c *Client) CreateLoadBalancerHealthMonitor(ctx context.Context, req *LoadBalancerHealthMonitorRequest) (*LoadBalancerHealthMonitor, error) {
+	monitor := &LoadBalancerHealthMonitor{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, loadBalancerHealthMonitorBasePath, req, monitor); err != nil {
+		return nil, err
+	}
+	return monitor, nil
+}
+
+// GetLoadBalancerHealthMonitor returns a health monitor. Health monitors have
+// no name, the health monitor is looked up by its pool if the UUID is empty.
+func (c *Client) GetLoadBalancerHealthMonitor(ctx context.Context, uuid, poolUUID string) (*LoadBalancerHealthMonitor, error) {
+	if uuid != "" {
+		monitor := &LoadBalancerHealthMonitor{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", loadBalancerHealthMonitorBasePath, uuid), nil, monitor); err != nil {
+			return nil, err
+		}
+		return monitor, nil
+	}
+	monitors := []LoadBalancerHealthMonitor{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, loadBalancerHealthMonitorBasePath, nil, &monitors); err != nil {
+		return nil, err
+	}
+	for _, m := range monitors {
+		if m.Pool.UUID == poolUUID {
+			return &m, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Load balancer health monitor not found")
+}
+
+// UpdateLoadBalancerHealthMonitor updates the timings, thresholds and HTTP
+// options of a health monitor
+func (c *Client) UpdateLoadBalancerHealthMonitor(ctx context.Context, uuid string, req *LoadBalancerHealthMonitorRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", loadBalancerHealthMonitorBasePath, uuid), req, nil)
+}
+
+// DeleteLoadBalancerHealthMonitor deletes a health monitor
+func (c *Client) DeleteLoadBalancerHealthMonitor(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", loadBalancerHealthMonitorBasePath, uuid), nil, nil)
+}
+
+func memberBasePath(poolUUID string) string {
+	return fmt.Sprintf("%s/%s/members", loadBalancerPoolBasePath, poolUUID)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package network contains the client of the Cloudscale network resources.
+// The SDK doesn't support networks yet, the API is called directly.
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const networkBasePath = "v1/networks"
+
+// Service defines Network Client operations
+type Service interface {
+	CreateNetwork(ctx context.Context, req *NetworkRequest) (*Network, error)
+	GetNetwork(ctx context.Context, uuid, name string) (*Network, error)
+	UpdateNetwork(ctx context.Context, uuid string, req *NetworkRequest) error
+	DeleteNetwork(ctx context.Context, uuid string) error
+	CreateSubnet(ctx context.Context, req *SubnetRequest) (*Subnet, error)
+	GetSubnet(ctx context.Context, uuid, networkUUID, cidr string) (*Subnet, error)
+	UpdateSubnet(ctx context.Context, uuid string, req *SubnetRequest) error
+	DeleteSubnet(ctx context.Context, uuid string) error
+	CreateFloatingIP(ctx context.Context, req *FloatingIPRequest) (*cloudscale.FloatingIP, error)
+	GetFloatingIP(ctx context.Context, ip string) (*cloudscale.FloatingIP, error)
+	UpdateFloatingIP(ctx context.Context, ip string, req *FloatingIPUpdateRequest) error
+	DeleteFloatingIP(ctx context.Context, ip string) error
+	CreateLoadBalancer(ctx context.Context, req *LoadBalancerRequest) (*LoadBalancer, error)
+	GetLoadBalancer(ctx context.Context, uuid, name string) (*LoadBalancer, error)
+	UpdateLoadBalancer(ctx context.Context, uuid string, req *LoadBalancerRequest) error
+	DeleteLoadBalancer(ctx context.Context, uuid string) error
+	CreateLoadBalancerPool(ctx context.Context, req *LoadBalancerPoolRequest) (*LoadBalancerPool, error)
+	GetLoadBalancerPool(ctx context.Context, uuid, loadBalancerUUID, name string) (*LoadBalancerPool, error)
+	UpdateLoadBalancerPool(ctx context.Context, uuid string, req *LoadBalancerPoolRequest) error
+	DeleteLoadBalancerPool(ctx context.Context, uuid string) error
+	CreateLoadBalancerPoolMember(ctx context.Context, poolUUID string, req *LoadBalancerPoolMemberRequest) (*LoadBalancerPoolMember, error)
+	GetLoadBalancerPoolMember(ctx context.Context, poolUUID, uuid, name string) (*LoadBalancerPoolMember, error)
+	UpdateLoadBalancerPoolMember(ctx context.Context, poolUUID, uuid string, req *LoadBalancerPoolMemberRequest) error
+	DeleteLoadBalancerPoolMember(ctx context.Context, poolUUID, uuid string) error
+	CreateLoadBalancerListener(ctx context.Context, req *LoadBalancerListenerRequest) (*LoadBalancerListener, error)
+	GetLoadBalancerListener(ctx context.Context, uuid, poolUUID, name string) (*LoadBalancerListener, error)
+	UpdateLoadBalancerListener(ctx context.Context, uuid string, req *LoadBalancerListenerRequest) error
+	DeleteLoadBalancerListener(ctx context.Context, uuid string) error
+	CreateLoadBalancerHealthMonitor(ctx context.Context, req *LoadBalancerHealthMonitorRequest) (*LoadBalancerHealthMonitor, error)
+	GetLoadBalancerHealthMonitor(ctx context.Context, uuid, poolUUID string) (*LoadBalancerHealthMonitor, error)
+	UpdateLoadBalancerHealthMonitor(ctx context.Context, uuid string, req *LoadBalancerHealthMonitorRequest) error
+	DeleteLoadBalancerHealthMonitor(ctx context.Context, uuid string) error
+}
+
+// Client implements the Network Client
+type Client struct {
+	cloudscaleClient *cloudscale.Client
+}
+
+// NewClient creates a new Network Client with the provided Provider configuration
+func NewClient(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) Service {
+	return &Client{
+		cloudscaleClient: clients.NewCloudscaleClient(config, httpClient),
+	}
+}
+
+// Network is a private network
+type Network struct {
+	cloudscale.ZonalResource
+	HREF    string       `json:"href"`
+	UUID    string       `json:"uuid"`
+	Name    string       `json:"name"`
+	MTU     int          `json:"mtu"`
+	Subnets []SubnetStub `json:"subnets"`
+}
+
+// NetworkStub is the reference to a network
+type NetworkStub struct {
+	HREF string `json:"href"`
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// SubnetStub is the reference to a subnet
+type SubnetStub struct {
+	HREF string `json:"href"`
+	UUID string `json:"uuid"`
+	CIDR string `json:"cidr"`
+}
+
+// NetworkRequest creates or updates a network. Only the name and MTU can be
+// updated.
+type NetworkRequest struct {
+	cloudscale.ZonalResourceRequest
+	Name                 string `json:"name,omitempty"`
+	MTU                  int    `json:"mtu,omitempty"`
+	AutoCreateIPv4Subnet *bool  `json:"auto_create_ipv4_subnet,omitempty"`
+}
+
+// CreateNetwork creates a network
+func (c *Client) CreateNetwork(ctx context.Context, req *NetworkRequest) (*Network, error) {
+	network := &Network{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, networkBasePath, req, network); err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+// GetNetwork returns a network. The network is looked up by its name if the
+// UUID is empty.
+func (c *Client) GetNetwork(ctx context.Context, uuid, name string) (*Network, error) {
+	if uuid != "" {
+		network := &Network{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", networkBasePath, uuid), nil, network); err != nil {
+			return nil, err
+		}
+		return network, nil
+	}
+	networks := []Network{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, networkBasePath, nil, &networks); err != nil {
+		return nil, err
+	}
+	for _, n := range networks {
+		if n.Name == name {
+			return &n, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Network not found")
+}
+
+// UpdateNetwork updates the name and MTU of a network
+func (c *Client) UpdateNetwork(ctx context.Context, uuid string, req *NetworkRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", networkBasePath, uuid), req, nil)
+}
+
+// DeleteNetwork deletes a network together with its subnets
+func (c *Client) DeleteNetwork(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", networkBasePath, uuid), nil, nil)
+}
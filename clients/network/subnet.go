@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const subnetBasePath = "v1/subnets"
+
+// Subnet is a subnet of a private network
+type Subnet struct {
+	HREF           string      `json:"href"`
+	UUID           string      `json:"uuid"`
+	CIDR           string      `json:"cidr"`
+	Network        NetworkStub `json:"network"`
+	GatewayAddress string      `json:"gateway_address"`
+	DNSServers     []string    `json:"dns_servers"`
+}
+
+// SubnetRequest creates or updates a subnet. Only the gateway address and
+// DNS servers can be updated.
+type SubnetRequest struct {
+	CIDR           string    `json:"cidr,omitempty"`
+	Network        string    `json:"network,omitempty"`
+	GatewayAddress *string   `json:"gateway_address,omitempty"`
+	DNSServers     *[]string `json:"dns_servers,omitempty"`
+}
+
+// CreateSubnet creates a subnet
+func (c *Client) CreateSubnet(ctx context.Context, req *SubnetRequest) (*Subnet, error) {
+	subnet := &Subnet{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, subnetBasePath, req, subnet); err != nil {
+		return nil, err
+	}
+	return subnet, nil
+}
+
+// GetSubnet returns a subnet. The subnet is looked up by its network and CIDR
+// if the UUID is empty.
+func (c *Client) GetSubnet(ctx context.Context, uuid, networkUUID, cidr string) (*Subnet, error) {
+	if uuid != "" {
+		subnet := &Subnet{}
+		if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, fmt.Sprintf("%s/%s", subnetBasePath, uuid), nil, subnet); err != nil {
+			return nil, err
+		}
+		return subnet, nil
+	}
+	subnets := []Subnet{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodGet, subnetBasePath, nil, &subnets); err != nil {
+		return nil, err
+	}
+	for _, s := range subnets {
+		if s.Network.UUID == networkUUID && s.CIDR == cidr {
+			return &s, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("Subnet not found")
+}
+
+// UpdateSubnet updates the gateway address and DNS servers of a subnet
+func (c *Client) UpdateSubnet(ctx context.Context, uuid string, req *SubnetRequest) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodPatch, fmt.Sprintf("%s/%s", subnetBasePath, uuid), req, nil)
+}
+
+// DeleteSubnet deletes a subnet
+func (c *Client) DeleteSubnet(ctx context.Context, uuid string) error {
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, fmt.Sprintf("%s/%s", subnetBasePath, uuid), nil, nil)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+)
+
+// errBucketNotOwned is returned if a bucket to adopt is owned by another
+// objects user.
+var errBucketNotOwned = errors.New("bucket is owned by another objects user")
+
+// IsErrorNotOwned returns true if the bucket to adopt is owned by another
+// objects user.
+func IsErrorNotOwned(err error) bool {
+	return errors.Cause(err) == errBucketNotOwned
+}
+
+// AdoptBucket returns the existing objects user with the supplied ID or
+// display name if it owns the existing bucket. Neither the objects user nor
+// the bucket are created or modified. A not found error is returned if either
+// of them doesn't exist.
+func (c *Client) AdoptBucket(ctx context.Context, user, bucketName, region string) (*cloudscale.ObjectsUser, error) {
+	objectsUser, err := c.cloudscaleClient.ObjectsUsers.Get(ctx, user)
+	if IsErrorNotFound(err) {
+		objectsUser, err = c.getExistingUser(ctx, "", user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	accessKey, secretKey, err := GetKeys(objectsUser)
+	if err != nil {
+		return nil, err
+	}
+	s3Client := c.getS3Client(accessKey, secretKey, region)
+
+	// Only the buckets owned by the authenticated user are listed.
+	buckets, err := s3Client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range buckets.Buckets {
+		if aws.StringValue(b.Name) == bucketName {
+			return objectsUser, nil
+		}
+	}
+
+	hreq := &s3.HeadBucketInput{
+		Bucket: aws.String(bucketName),
+	}
+	_, err = s3Client.HeadBucketWithContext(ctx, hreq)
+	switch {
+	case IsErrorNotFound(err):
+		return nil, err
+	case err == nil || isErrorAccessDenied(err):
+		// The bucket exists, but isn't listed as one of the user's.
+		return nil, errBucketNotOwned
+	}
+	return nil, errors.Wrap(err, "cannot get bucket to adopt")
+}
+
+// isErrorAccessDenied returns true if an S3 request was denied. Responses to
+// HEAD requests have no body, so only their status code is available.
+func isErrorAccessDenied(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusForbidden {
+		return true
+	}
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "AccessDenied"
+}
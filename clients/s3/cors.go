@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+)
+
+const errCodeNoSuchCORSConfiguration = "NoSuchCORSConfiguration"
+
+// SetBucketCORSRules replaces the CORS configuration of the bucket with the
+// supplied rules. The configuration is removed if no rules are given.
+func (c *Client) SetBucketCORSRules(ctx context.Context, userID, bucketName, region string, rules []storagev1alpha1.CORSRule) error {
+	s3Client, err := c.getBucketS3Client(ctx, userID, bucketName, region)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		dreq := &s3.DeleteBucketCorsInput{
+			Bucket: aws.String(bucketName),
+		}
+		_, err = s3Client.DeleteBucketCorsWithContext(ctx, dreq)
+		return err
+	}
+
+	creq := &s3.PutBucketCorsInput{
+		Bucket: aws.String(bucketName),
+		CORSConfiguration: &s3.CORSConfiguration{
+			CORSRules: generateCORSRules(rules),
+		},
+	}
+	_, err = s3Client.PutBucketCorsWithContext(ctx, creq)
+	return err
+}
+
+func getBucketCORSRules(ctx context.Context, s3Client *s3.S3, bucketName string) ([]storagev1alpha1.CORSRule, error) {
+	creq := &s3.GetBucketCorsInput{
+		Bucket: aws.String(bucketName),
+	}
+	cors, err := s3Client.GetBucketCorsWithContext(ctx, creq)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == errCodeNoSuchCORSConfiguration {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return observeCORSRules(cors.CORSRules), nil
+}
+
+func generateCORSRules(rules []storagev1alpha1.CORSRule) []*s3.CORSRule {
+	cr := make([]*s3.CORSRule, len(rules))
+	for i, r := range rules {
+		cr[i] = &s3.CORSRule{
+			AllowedOrigins: aws.StringSlice(r.AllowedOrigins),
+			AllowedMethods: aws.StringSlice(r.AllowedMethods),
+			AllowedHeaders: aws.StringSlice(r.AllowedHeaders),
+			ExposeHeaders:  aws.StringSlice(r.ExposeHeaders),
+			MaxAgeSeconds:  r.MaxAgeSeconds,
+		}
+	}
+	return cr
+}
+
+func observeCORSRules(rules []*s3.CORSRule) []storagev1alpha1.CORSRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	cr := make([]storagev1alpha1.CORSRule, len(rules))
+	for i, r := range rules {
+		cr[i] = storagev1alpha1.CORSRule{
+			AllowedOrigins: observeStrings(r.AllowedOrigins),
+			AllowedMethods: observeStrings(r.AllowedMethods),
+			AllowedHeaders: observeStrings(r.AllowedHeaders),
+			ExposeHeaders:  observeStrings(r.ExposeHeaders),
+			MaxAgeSeconds:  r.MaxAgeSeconds,
+		}
+	}
+	return cr
+}
+
+func observeStrings(s []*string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return aws.StringValueSlice(s)
+}
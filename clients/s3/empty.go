@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// emptyBucketWorkers is the number of requests sent in parallel while
+// emptying a bucket
+const emptyBucketWorkers = 8
+
+type task func(ctx context.Context) error
+
+// emptyS3Bucket aborts all incomplete multipart uploads and deletes all
+// objects including their versions and delete markers.
+func emptyS3Bucket(ctx context.Context, s3Client *s3.S3, bucketName string) error {
+	err := parallel(ctx, func(ctx context.Context, tasks chan<- task) error {
+		return listMultipartUploads(ctx, s3Client, bucketName, tasks)
+	})
+	if err != nil {
+		return err
+	}
+	return parallel(ctx, func(ctx context.Context, tasks chan<- task) error {
+		return listObjectVersions(ctx, s3Client, bucketName, tasks)
+	})
+}
+
+func listMultipartUploads(ctx context.Context, s3Client *s3.S3, bucketName string, tasks chan<- task) error {
+	lreq := &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucketName),
+	}
+	return s3Client.ListMultipartUploadsPagesWithContext(ctx, lreq, func(page *s3.ListMultipartUploadsOutput, _ bool) bool {
+		for _, u := range page.Uploads {
+			areq := &s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucketName),
+				Key:      u.Key,
+				UploadId: u.UploadId,
+			}
+			t := func(ctx context.Context) error {
+				_, err := s3Client.AbortMultipartUploadWithContext(ctx, areq)
+				return err
+			}
+			if !submit(ctx, tasks, t) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// listObjectVersions submits a batched delete for each page of object
+// versions. Unversioned objects are listed with a null version. A page never
+// contains more objects than a single delete request accepts.
+func listObjectVersions(ctx context.Context, s3Client *s3.S3, bucketName string, tasks chan<- task) error {
+	lreq := &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucketName),
+	}
+	return s3Client.ListObjectVersionsPagesWithContext(ctx, lreq, func(page *s3.ListObjectVersionsOutput, _ bool) bool {
+		objects := make([]*s3.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
+		for _, v := range page.Versions {
+			objects = append(objects, &s3.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range page.DeleteMarkers {
+			objects = append(objects, &s3.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+		}
+		if len(objects) == 0 {
+			return true
+		}
+		dreq := &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &s3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		}
+		t := func(ctx context.Context) error {
+			out, err := s3Client.DeleteObjectsWithContext(ctx, dreq)
+			if err != nil {
+				return err
+			}
+			if len(out.Errors) > 0 {
+				e := out.Errors[0]
+				return fmt.Errorf("cannot delete object %s: %s", aws.StringValue(e.Key), aws.StringValue(e.Message))
+			}
+			return nil
+		}
+		return submit(ctx, tasks, t)
+	})
+}
+
+// submit a task unless the context is done.
+func submit(ctx context.Context, tasks chan<- task, t task) bool {
+	select {
+	case tasks <- t:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// parallel runs the tasks submitted by list on a fixed number of workers. It
+// stops at the first error and returns it.
+func parallel(ctx context.Context, list func(ctx context.Context, tasks chan<- task) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	tasks := make(chan task)
+	var wg sync.WaitGroup
+	for i := 0; i < emptyBucketWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := t(ctx); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	err := list(ctx, tasks)
+	close(tasks)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return err
+}
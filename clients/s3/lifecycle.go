@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+)
+
+const errCodeNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
+
+// SetBucketLifecycleRules replaces the lifecycle configuration of the bucket
+// with the supplied rules. The configuration is removed if no rules are given.
+func (c *Client) SetBucketLifecycleRules(ctx context.Context, userID, bucketName, region string, rules []storagev1alpha1.LifecycleRule) error {
+	s3Client, err := c.getBucketS3Client(ctx, userID, bucketName, region)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) == 0 {
+		dreq := &s3.DeleteBucketLifecycleInput{
+			Bucket: aws.String(bucketName),
+		}
+		_, err = s3Client.DeleteBucketLifecycleWithContext(ctx, dreq)
+		return err
+	}
+
+	lreq := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: generateLifecycleRules(rules),
+		},
+	}
+	_, err = s3Client.PutBucketLifecycleConfigurationWithContext(ctx, lreq)
+	return err
+}
+
+func getBucketLifecycleRules(ctx context.Context, s3Client *s3.S3, bucketName string) ([]storagev1alpha1.LifecycleRule, error) {
+	lreq := &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+	}
+	lifecycle, err := s3Client.GetBucketLifecycleConfigurationWithContext(ctx, lreq)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == errCodeNoSuchLifecycleConfiguration {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return observeLifecycleRules(lifecycle.Rules), nil
+}
+
+func generateLifecycleRules(rules []storagev1alpha1.LifecycleRule) []*s3.LifecycleRule {
+	lr := make([]*s3.LifecycleRule, len(rules))
+	for i, r := range rules {
+		rule := &s3.LifecycleRule{
+			ID:     aws.String(r.ID),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: generateLifecycleRuleFilter(r.Prefix, r.Tags),
+		}
+		if r.Disabled {
+			rule.Status = aws.String(s3.ExpirationStatusDisabled)
+		}
+		if r.ExpirationDays != nil {
+			rule.Expiration = &s3.LifecycleExpiration{Days: r.ExpirationDays}
+		}
+		if r.NoncurrentVersionExpirationDays != nil {
+			rule.NoncurrentVersionExpiration = &s3.NoncurrentVersionExpiration{NoncurrentDays: r.NoncurrentVersionExpirationDays}
+		}
+		if r.AbortIncompleteMultipartUploadDays != nil {
+			rule.AbortIncompleteMultipartUpload = &s3.AbortIncompleteMultipartUpload{DaysAfterInitiation: r.AbortIncompleteMultipartUploadDays}
+		}
+		lr[i] = rule
+	}
+	return lr
+}
+
+func generateLifecycleRuleFilter(prefix string, tags map[string]string) *s3.LifecycleRuleFilter {
+	if len(tags) == 0 {
+		return &s3.LifecycleRuleFilter{Prefix: aws.String(prefix)}
+	}
+
+	// Sort the tags so the generated configuration is stable
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s3Tags := make([]*s3.Tag, len(keys))
+	for i, k := range keys {
+		s3Tags[i] = &s3.Tag{Key: aws.String(k), Value: aws.String(tags[k])}
+	}
+
+	if prefix == "" && len(s3Tags) == 1 {
+		return &s3.LifecycleRuleFilter{Tag: s3Tags[0]}
+	}
+	return &s3.LifecycleRuleFilter{
+		And: &s3.LifecycleRuleAndOperator{
+			Prefix: aws.String(prefix),
+			Tags:   s3Tags,
+		},
+	}
+}
+
+func observeLifecycleRules(rules []*s3.LifecycleRule) []storagev1alpha1.LifecycleRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	lr := make([]storagev1alpha1.LifecycleRule, len(rules))
+	for i, r := range rules {
+		rule := storagev1alpha1.LifecycleRule{
+			ID:       aws.StringValue(r.ID),
+			Disabled: aws.StringValue(r.Status) == s3.ExpirationStatusDisabled,
+			// The top level prefix is deprecated, but still returned by
+			// some S3 implementations.
+			Prefix: aws.StringValue(r.Prefix),
+		}
+		if f := r.Filter; f != nil {
+			switch {
+			case f.And != nil:
+				rule.Prefix = aws.StringValue(f.And.Prefix)
+				rule.Tags = observeTags(f.And.Tags)
+			case f.Tag != nil:
+				rule.Tags = observeTags([]*s3.Tag{f.Tag})
+			case f.Prefix != nil:
+				rule.Prefix = aws.StringValue(f.Prefix)
+			}
+		}
+		if r.Expiration != nil {
+			rule.ExpirationDays = r.Expiration.Days
+		}
+		if r.NoncurrentVersionExpiration != nil {
+			rule.NoncurrentVersionExpirationDays = r.NoncurrentVersionExpiration.NoncurrentDays
+		}
+		if r.AbortIncompleteMultipartUpload != nil {
+			rule.AbortIncompleteMultipartUploadDays = r.AbortIncompleteMultipartUpload.DaysAfterInitiation
+		}
+		lr[i] = rule
+	}
+	return lr
+}
+
+func observeTags(tags []*s3.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	t := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		t[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return t
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+// objectsUserKeysPath is the path of the keys of an objects user
+const objectsUserKeysPath = "v1/objects-users/%s/keys"
+
+// CreateOrUpdateObjectsUser creates an objects user or updates the display
+// name and tags of an existing one. An existing user is looked up by its
+// display name if the ID is empty.
+func (c *Client) CreateOrUpdateObjectsUser(ctx context.Context, userID, displayName string, tags *map[string]string) (*cloudscale.ObjectsUser, error) {
+	userTags := map[string]string{}
+	if tags != nil {
+		userTags = *tags
+	}
+	objectUserRequest := &cloudscale.ObjectsUserRequest{
+		DisplayName: displayName,
+		Tags:        userTags,
+	}
+	existingUser, err := c.getExistingUser(ctx, userID, displayName)
+
+	switch {
+	case IsErrorNotFound(err):
+		return c.cloudscaleClient.ObjectsUsers.Create(ctx, objectUserRequest)
+	case err != nil:
+		return nil, err
+	}
+
+	if err := c.cloudscaleClient.ObjectsUsers.Update(ctx, existingUser.ID, objectUserRequest); err != nil {
+		return nil, err
+	}
+	return c.cloudscaleClient.ObjectsUsers.Get(ctx, existingUser.ID)
+}
+
+// CreateObjectsUser creates a new objects user, regardless of existing users
+// with the same display name.
+func (c *Client) CreateObjectsUser(ctx context.Context, displayName string, tags map[string]string) (*cloudscale.ObjectsUser, error) {
+	return c.cloudscaleClient.ObjectsUsers.Create(ctx, &cloudscale.ObjectsUserRequest{
+		DisplayName: displayName,
+		Tags:        tags,
+	})
+}
+
+// GetObjectsUser returns an objects user. The user is looked up by its
+// display name if the ID is empty.
+func (c *Client) GetObjectsUser(ctx context.Context, userID, displayName string) (*cloudscale.ObjectsUser, error) {
+	return c.getExistingUser(ctx, userID, displayName)
+}
+
+// FindObjectsUser returns the objects user which has all of the supplied
+// tags, or a not found error if there is none.
+func (c *Client) FindObjectsUser(ctx context.Context, tags map[string]string) (*cloudscale.ObjectsUser, error) {
+	objectUsers, err := c.cloudscaleClient.ObjectsUsers.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range objectUsers {
+		if hasTags(user.Tags, tags) {
+			return &user, nil
+		}
+	}
+	return nil, clients.ErrorNotFound("User not found")
+}
+
+func hasTags(tags, wanted map[string]string) bool {
+	for k, v := range wanted {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteObjectsUser deletes an objects user
+func (c *Client) DeleteObjectsUser(ctx context.Context, userID string) error {
+	return c.cloudscaleClient.ObjectsUsers.Delete(ctx, userID)
+}
+
+// CreateOrUpdateUserBucket creates a bucket owned by an existing objects
+// user, or updates the canned ACL of the bucket if it already exists
+func (c *Client) CreateOrUpdateUserBucket(ctx context.Context, userID, bucketName, region string, cannedACL *string) error {
+	objectUser, err := c.cloudscaleClient.ObjectsUsers.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	accessKey, secretKey, err := GetKeys(objectUser)
+	if err != nil {
+		return err
+	}
+	return c.createOrUpdateS3Bucket(ctx, bucketName, region, accessKey, secretKey, cannedACL)
+}
+
+// CreateObjectsUserKey adds a new key to the objects user and returns its
+// access and secret key. Existing keys stay valid.
+func (c *Client) CreateObjectsUserKey(ctx context.Context, userID string) (string, string, error) {
+	path := fmt.Sprintf(objectsUserKeysPath, userID)
+	key := map[string]string{}
+	if err := clients.Do(ctx, c.cloudscaleClient, http.MethodPost, path, nil, &key); err != nil {
+		return "", "", err
+	}
+	return key["access_key"], key["secret_key"], nil
+}
+
+// DeleteObjectsUserKey revokes a key of the objects user
+func (c *Client) DeleteObjectsUserKey(ctx context.Context, userID, accessKey string) error {
+	path := fmt.Sprintf(objectsUserKeysPath+"/%s", userID, accessKey)
+	return clients.Do(ctx, c.cloudscaleClient, http.MethodDelete, path, nil, nil)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+)
+
+const (
+	errCodeNoSuchBucketPolicy = "NoSuchBucketPolicy"
+
+	policyVersion          = "2012-10-17"
+	policyUserARNFormat    = "arn:aws:iam:::user/%s"
+	policyBucketARNFormat  = "arn:aws:s3:::%s"
+	policyObjectsARNFormat = "arn:aws:s3:::%s/*"
+	policyEffectAllow      = "Allow"
+	policyStatementKey     = "Statement"
+	policyVersionKey       = "Version"
+)
+
+// bucketActions and objectActions are the actions allowed on the bucket and
+// its objects per access level.
+var (
+	bucketActions = map[storagev1alpha1.BucketAccess][]string{
+		storagev1alpha1.BucketAccessRead:  {"s3:ListBucket", "s3:GetBucketLocation"},
+		storagev1alpha1.BucketAccessWrite: {"s3:ListBucketMultipartUploads"},
+	}
+	objectActions = map[storagev1alpha1.BucketAccess][]string{
+		storagev1alpha1.BucketAccessRead:  {"s3:GetObject"},
+		storagev1alpha1.BucketAccessWrite: {"s3:PutObject", "s3:DeleteObject", "s3:AbortMultipartUpload", "s3:ListMultipartUploadParts"},
+	}
+)
+
+// AccessGrant grants an objects user access to a bucket.
+type AccessGrant struct {
+	UserID string
+	Access storagev1alpha1.BucketAccess
+}
+
+// SetBucketPolicy replaces the policy of the bucket with the supplied JSON
+// document. The policy is removed if the document is empty.
+func (c *Client) SetBucketPolicy(ctx context.Context, userID, bucketName, region, policy string) error {
+	s3Client, err := c.getBucketS3Client(ctx, userID, bucketName, region)
+	if err != nil {
+		return err
+	}
+
+	if policy == "" {
+		dreq := &s3.DeleteBucketPolicyInput{
+			Bucket: aws.String(bucketName),
+		}
+		_, err = s3Client.DeleteBucketPolicyWithContext(ctx, dreq)
+		return err
+	}
+
+	preq := &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(policy),
+	}
+	_, err = s3Client.PutBucketPolicyWithContext(ctx, preq)
+	return err
+}
+
+func getBucketPolicy(ctx context.Context, s3Client *s3.S3, bucketName string) (string, error) {
+	preq := &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	}
+	policy, err := s3Client.GetBucketPolicyWithContext(ctx, preq)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == errCodeNoSuchBucketPolicy {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(policy.Policy), nil
+}
+
+// PolicyEqual returns true if both policy documents are semantically equal,
+// i.e. they only differ in formatting. Documents which are no valid JSON are
+// compared literally.
+func PolicyEqual(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	var pa, pb interface{}
+	if err := json.Unmarshal([]byte(a), &pa); err != nil {
+		return a == b
+	}
+	if err := json.Unmarshal([]byte(b), &pb); err != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(pa, pb)
+}
+
+// AccessPolicy returns the bucket policy consisting of the statements of the
+// supplied policy document and the statements implementing the grants. The
+// policy is returned unchanged if there are no grants.
+func AccessPolicy(bucketName, policy string, grants []AccessGrant) (string, error) {
+	if len(grants) == 0 {
+		return policy, nil
+	}
+
+	doc := map[string]interface{}{policyVersionKey: policyVersion}
+	if policy != "" {
+		if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+			return "", errors.Wrap(err, "cannot parse bucket policy")
+		}
+	}
+
+	// A policy may contain a single statement instead of a list.
+	var statements []interface{}
+	switch s := doc[policyStatementKey].(type) {
+	case []interface{}:
+		statements = s
+	case nil:
+	default:
+		statements = []interface{}{s}
+	}
+
+	for _, g := range grants {
+		principal := map[string]interface{}{"AWS": []string{fmt.Sprintf(policyUserARNFormat, g.UserID)}}
+		statements = append(statements,
+			map[string]interface{}{
+				"Effect":    policyEffectAllow,
+				"Principal": principal,
+				"Action":    bucketActions[g.Access],
+				"Resource":  []string{fmt.Sprintf(policyBucketARNFormat, bucketName)},
+			},
+			map[string]interface{}{
+				"Effect":    policyEffectAllow,
+				"Principal": principal,
+				"Action":    objectActions[g.Access],
+				"Resource":  []string{fmt.Sprintf(policyObjectsARNFormat, bucketName)},
+			},
+		)
+	}
+	doc[policyStatementKey] = statements
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -19,7 +19,6 @@ package s3
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -30,15 +29,23 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
 )
 
-// S3EndpointFormat is the endpoint for the S3 API without the region
-const S3EndpointFormat = "https://objects.%s.cloudscale.ch"
+// DefaultCannedACL is the canned ACL of buckets which don't specify one
+const DefaultCannedACL = s3.BucketCannedACLPrivate
+
+const (
+	groupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	groupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
 
 // IsErrorNotFound helper function to test for BucketNotFound error
 func IsErrorNotFound(err error) bool {
-	if errResp, ok := err.(*cloudscale.ErrorResponse); ok {
-		return errResp.StatusCode == 404
+	if clients.IsErrorNotFound(err) {
+		return true
 	} else if awsErr, ok := err.(awserr.Error); ok {
 		code := awsErr.Code()
 		return code == s3.ErrCodeNoSuchBucket || code == "NotFound"
@@ -49,72 +56,73 @@ func IsErrorNotFound(err error) bool {
 // Service defines S3 Client operations
 type Service interface {
 	CreateOrUpdateBucket(ctx context.Context, userID, bucketName, region string, cannedACL *string, tags *map[string]string) (*cloudscale.ObjectsUser, error)
-	GetBucketInfo(ctx context.Context, userID, bucketName, region string) (*cloudscale.ObjectsUser, error)
-	DeleteBucket(ctx context.Context, userID, bucketName, region string) error
+	CreateOrUpdateUserBucket(ctx context.Context, userID, bucketName, region string, cannedACL *string) error
+	AdoptBucket(ctx context.Context, user, bucketName, region string) (*cloudscale.ObjectsUser, error)
+	GetBucketInfo(ctx context.Context, userID, bucketName, region string) (*BucketInfo, error)
+	DeleteBucket(ctx context.Context, userID, bucketName, region string, forceDestroy bool) error
+	SetBucketVersioning(ctx context.Context, userID, bucketName, region, status string) error
+	SetBucketLifecycleRules(ctx context.Context, userID, bucketName, region string, rules []storagev1alpha1.LifecycleRule) error
+	SetBucketCORSRules(ctx context.Context, userID, bucketName, region string, rules []storagev1alpha1.CORSRule) error
+	SetBucketPolicy(ctx context.Context, userID, bucketName, region, policy string) error
+	SetBucketWebsite(ctx context.Context, userID, bucketName, region string, website *storagev1alpha1.WebsiteConfiguration) error
+	GetBucketUsage(ctx context.Context, userID, bucketName, region string, limit int64) (*BucketUsage, error)
+	CreateOrUpdateObjectsUser(ctx context.Context, userID, displayName string, tags *map[string]string) (*cloudscale.ObjectsUser, error)
+	CreateObjectsUser(ctx context.Context, displayName string, tags map[string]string) (*cloudscale.ObjectsUser, error)
+	GetObjectsUser(ctx context.Context, userID, displayName string) (*cloudscale.ObjectsUser, error)
+	FindObjectsUser(ctx context.Context, tags map[string]string) (*cloudscale.ObjectsUser, error)
+	DeleteObjectsUser(ctx context.Context, userID string) error
+	CreateObjectsUserKey(ctx context.Context, userID string) (string, string, error)
+	DeleteObjectsUserKey(ctx context.Context, userID, accessKey string) error
+	Endpoint(region string) string
+	WebsiteEndpoint(bucketName, region string) string
+}
+
+// BucketInfo is the observed state of a bucket and the objects user owning it
+type BucketInfo struct {
+	User           *cloudscale.ObjectsUser
+	CannedACL      string
+	Versioning     string
+	LifecycleRules []storagev1alpha1.LifecycleRule
+	CORSRules      []storagev1alpha1.CORSRule
+	Policy         string
+	Website        *storagev1alpha1.WebsiteConfiguration
 }
 
 // Client implements S3 Client
 type Client struct {
 	cloudscaleClient *cloudscale.Client
+	httpClient       *http.Client
+	config           *clients.ProviderConfig
 }
 
-// NewClient creates a new S3 Client with provided Cloudscale credentials
-func NewClient(ctx context.Context, cloudscaleToken string, httpClient *http.Client) Service {
+// NewClient creates a new S3 Client with the provided Provider configuration.
+// The shared HTTP client of the token is used for both the Cloudscale API and
+// the object storage if httpClient is nil.
+func NewClient(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) Service {
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = clients.NewHTTPClient(config.Token)
 	}
-	c := &Client{
-		cloudscaleClient: cloudscale.NewClient(httpClient),
+	return &Client{
+		cloudscaleClient: clients.NewCloudscaleClient(config, httpClient),
+		httpClient:       httpClient,
+		config:           config,
 	}
-	c.cloudscaleClient.AuthToken = cloudscaleToken
-
-	return c
 }
 
 // CreateOrUpdateBucket creates or updates the supplied S3 bucket with provided
-// specification
+// specification, together with an objects user named after the bucket
 func (c *Client) CreateOrUpdateBucket(ctx context.Context, userID, bucketName, region string, cannedACL *string, tags *map[string]string) (*cloudscale.ObjectsUser, error) {
-	bucketTags := map[string]string{}
-	if tags != nil {
-		bucketTags = *tags
-	}
-	objectUserRequest := &cloudscale.ObjectsUserRequest{
-		DisplayName: bucketName,
-		Tags:        bucketTags,
-	}
-	var objectUser *cloudscale.ObjectsUser
-	existingUser, err := c.getExistingBucketUser(ctx, userID, bucketName, region)
-
-	switch {
-	case IsErrorNotFound(err):
-		objectUser, err = c.cloudscaleClient.ObjectsUsers.Create(ctx, objectUserRequest)
-		if err != nil {
-			return nil, err
-		}
-	case err != nil:
-		return nil, err
-	default:
-		err := c.cloudscaleClient.ObjectsUsers.Update(ctx, existingUser.ID, objectUserRequest)
-		if err != nil {
-			return nil, err
-		}
-		objectUser, err = c.getExistingBucketUser(ctx, userID, bucketName, region)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	accessKey, secretKey, err := GetKeys(objectUser)
+	objectUser, err := c.CreateOrUpdateObjectsUser(ctx, userID, bucketName, tags)
 	if err != nil {
 		return nil, err
 	}
-	err = createS3Bucket(bucketName, region, accessKey, secretKey, cannedACL)
+	err = c.CreateOrUpdateUserBucket(ctx, objectUser.ID, bucketName, region, cannedACL)
 	return objectUser, err
 }
 
 // GetBucketInfo returns the status of key bucket settings including user's policy version for permission status
-func (c *Client) GetBucketInfo(ctx context.Context, userID, bucketName, region string) (*cloudscale.ObjectsUser, error) {
-	existingBucketUser, err := c.getExistingBucketUser(ctx, userID, bucketName, region)
+func (c *Client) GetBucketInfo(ctx context.Context, userID, bucketName, region string) (*BucketInfo, error) {
+	existingBucketUser, err := c.getExistingUser(ctx, userID, bucketName)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +131,7 @@ func (c *Client) GetBucketInfo(ctx context.Context, userID, bucketName, region s
 		return nil, err
 	}
 
-	s3Client := getS3Client(accessKey, secretKey, region)
+	s3Client := c.getS3Client(accessKey, secretKey, region)
 	hreq := &s3.HeadBucketInput{
 		Bucket: aws.String(bucketName),
 	}
@@ -133,29 +141,93 @@ func (c *Client) GetBucketInfo(ctx context.Context, userID, bucketName, region s
 		return nil, err
 	}
 
-	return existingBucketUser, nil
+	areq := &s3.GetBucketAclInput{
+		Bucket: aws.String(bucketName),
+	}
+	acl, err := s3Client.GetBucketAclWithContext(ctx, areq)
+	if err != nil {
+		return nil, err
+	}
+
+	vreq := &s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+	}
+	versioning, err := s3Client.GetBucketVersioningWithContext(ctx, vreq)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycleRules, err := getBucketLifecycleRules(ctx, s3Client, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	corsRules, err := getBucketCORSRules(ctx, s3Client, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := getBucketPolicy(ctx, s3Client, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	website, err := getBucketWebsite(ctx, s3Client, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &BucketInfo{
+		User:           existingBucketUser,
+		CannedACL:      cannedACLFromGrants(acl.Grants),
+		Versioning:     aws.StringValue(versioning.Status),
+		LifecycleRules: lifecycleRules,
+		CORSRules:      corsRules,
+		Policy:         policy,
+		Website:        website,
+	}
+	return info, nil
 }
 
-// DeleteBucket deletes s3 bucket, and related User
-func (c *Client) DeleteBucket(ctx context.Context, userID, bucketName, region string) error {
-	existingBucketUser, err := c.getExistingBucketUser(ctx, userID, bucketName, region)
+// SetBucketVersioning sets the versioning state of the bucket to either
+// Enabled or Suspended
+func (c *Client) SetBucketVersioning(ctx context.Context, userID, bucketName, region, status string) error {
+	s3Client, err := c.getBucketS3Client(ctx, userID, bucketName, region)
 	if err != nil {
 		return err
 	}
-	accessKey, secretKey, err := GetKeys(existingBucketUser)
+	vreq := &s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String(status),
+		},
+	}
+	_, err = s3Client.PutBucketVersioningWithContext(ctx, vreq)
+	return err
+}
+
+// DeleteBucket deletes s3 bucket. If forceDestroy is set, the bucket is
+// emptied first. The objects user owning the bucket is not deleted.
+func (c *Client) DeleteBucket(ctx context.Context, userID, bucketName, region string, forceDestroy bool) error {
+	existingBucketUser, err := c.getExistingUser(ctx, userID, bucketName)
 	if err != nil {
 		return err
 	}
-	err = deleteS3Bucket(bucketName, region, accessKey, secretKey)
+	accessKey, secretKey, err := GetKeys(existingBucketUser)
 	if err != nil {
 		return err
 	}
-	return c.cloudscaleClient.ObjectsUsers.Delete(ctx, existingBucketUser.ID)
+	if forceDestroy {
+		if err := emptyS3Bucket(ctx, c.getS3Client(accessKey, secretKey, region), bucketName); err != nil {
+			return err
+		}
+	}
+	return c.deleteS3Bucket(bucketName, region, accessKey, secretKey)
 }
 
-func (c *Client) getExistingBucketUser(ctx context.Context, userID, bucketName, region string) (*cloudscale.ObjectsUser, error) {
+func (c *Client) getExistingUser(ctx context.Context, userID, displayName string) (*cloudscale.ObjectsUser, error) {
 	if userID == "" {
-		b, err := c.lookupUserByName(ctx, bucketName)
+		b, err := c.lookupUserByName(ctx, displayName)
 		if err != nil {
 			return nil, err
 		}
@@ -164,37 +236,104 @@ func (c *Client) getExistingBucketUser(ctx context.Context, userID, bucketName,
 	return c.cloudscaleClient.ObjectsUsers.Get(ctx, userID)
 }
 
-func createS3Bucket(bucketName, region, accessKey, secretKey string, cannedACL *string) error {
-	acl := aws.String(s3.BucketCannedACLPrivate)
+// getBucketS3Client returns an S3 client authenticated as the bucket's user
+func (c *Client) getBucketS3Client(ctx context.Context, userID, bucketName, region string) (*s3.S3, error) {
+	existingBucketUser, err := c.getExistingUser(ctx, userID, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	accessKey, secretKey, err := GetKeys(existingBucketUser)
+	if err != nil {
+		return nil, err
+	}
+	return c.getS3Client(accessKey, secretKey, region), nil
+}
+
+func (c *Client) createOrUpdateS3Bucket(ctx context.Context, bucketName, region, accessKey, secretKey string, cannedACL *string) error {
+	acl := aws.String(DefaultCannedACL)
 	if cannedACL != nil {
 		acl = cannedACL
 	}
 	bucket := aws.String(bucketName)
-	cparams := &s3.CreateBucketInput{
+	s3Client := c.getS3Client(accessKey, secretKey, region)
+
+	_, err := s3Client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: bucket})
+	if IsErrorNotFound(err) {
+		cparams := &s3.CreateBucketInput{
+			Bucket: bucket,
+			ACL:    acl,
+		}
+		_, err = s3Client.CreateBucketWithContext(ctx, cparams)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	aparams := &s3.PutBucketAclInput{
 		Bucket: bucket,
 		ACL:    acl,
 	}
-	s3Client := getS3Client(accessKey, secretKey, region)
-	_, err := s3Client.CreateBucket(cparams)
+	_, err = s3Client.PutBucketAclWithContext(ctx, aparams)
 	return err
 }
 
-func deleteS3Bucket(bucketName, region, accessKey, secretKey string) error {
+// cannedACLFromGrants maps the grants of a bucket ACL back to the canned ACL
+// which produces them. Grants to individual users are ignored.
+func cannedACLFromGrants(grants []*s3.Grant) string {
+	var allUsersRead, allUsersWrite, authenticatedRead bool
+	for _, g := range grants {
+		if g.Grantee == nil || g.Grantee.URI == nil || g.Permission == nil {
+			continue
+		}
+		switch *g.Grantee.URI {
+		case groupAllUsers:
+			allUsersRead = allUsersRead || *g.Permission == s3.PermissionRead
+			allUsersWrite = allUsersWrite || *g.Permission == s3.PermissionWrite
+		case groupAuthenticatedUsers:
+			authenticatedRead = authenticatedRead || *g.Permission == s3.PermissionRead
+		}
+	}
+	switch {
+	case allUsersRead && allUsersWrite:
+		return s3.BucketCannedACLPublicReadWrite
+	case allUsersRead:
+		return s3.BucketCannedACLPublicRead
+	case authenticatedRead:
+		return s3.BucketCannedACLAuthenticatedRead
+	default:
+		return s3.BucketCannedACLPrivate
+	}
+}
+
+func (c *Client) deleteS3Bucket(bucketName, region, accessKey, secretKey string) error {
 	dparams := &s3.DeleteBucketInput{
 		Bucket: aws.String(bucketName),
 	}
-	s3Client := getS3Client(accessKey, secretKey, region)
+	s3Client := c.getS3Client(accessKey, secretKey, region)
 	_, err := s3Client.DeleteBucket(dparams)
 	return err
 }
 
-func getS3Client(accessKey, secretKey, region string) *s3.S3 {
+// Endpoint returns the endpoint of the S3 API in the region
+func (c *Client) Endpoint(region string) string {
+	return c.config.ObjectStorageEndpoint(region)
+}
+
+// WebsiteEndpoint returns the endpoint of the bucket in the region served as
+// static website
+func (c *Client) WebsiteEndpoint(bucketName, region string) string {
+	return c.config.ObjectStorageWebsiteEndpoint(bucketName, region)
+}
+
+func (c *Client) getS3Client(accessKey, secretKey, region string) *s3.S3 {
 	s3Config := &aws.Config{
 		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:         aws.String(fmt.Sprintf(S3EndpointFormat, region)),
+		Endpoint:         aws.String(c.Endpoint(region)),
 		Region:           aws.String(region),
 		DisableSSL:       aws.Bool(false),
 		S3ForcePathStyle: aws.Bool(true),
+		HTTPClient:       c.httpClient,
 	}
 	newSession := session.New(s3Config)
 	return s3.New(newSession)
@@ -211,26 +350,34 @@ func (c *Client) lookupUserByName(ctx context.Context, userName string) (*clouds
 			return &user, nil
 		}
 	}
-	err = &cloudscale.ErrorResponse{
-		StatusCode: 404,
-		Message: map[string]string{
-			"Error": "User not found",
-		},
-	}
-	return nil, err
+	return nil, clients.ErrorNotFound("User not found")
 }
 
-// GetKeys returns the keys for a object user
+// GetKeys returns the keys for a object user. If the user has several keys,
+// e.g. during a key rotation, the first one is returned.
 func GetKeys(objectUser *cloudscale.ObjectsUser) (string, string, error) {
+	return GetKeysFor(objectUser, "")
+}
+
+// GetKeysFor returns the keys of a object user matching the access key. The
+// first key is returned if the access key is empty or doesn't exist anymore.
+func GetKeysFor(objectUser *cloudscale.ObjectsUser, accessKey string) (string, string, error) {
 	err := errors.New("Unexpected API return, keys found")
-	if len(objectUser.Keys) != 1 {
+	if len(objectUser.Keys) == 0 {
 		return "", "", err
 	}
-	accessKey, ok := objectUser.Keys[0]["access_key"]
+	key := objectUser.Keys[0]
+	for _, k := range objectUser.Keys {
+		if accessKey != "" && k["access_key"] == accessKey {
+			key = k
+			break
+		}
+	}
+	accessKey, ok := key["access_key"]
 	if !ok {
 		return "", "", err
 	}
-	secretKey, ok := objectUser.Keys[0]["secret_key"]
+	secretKey, ok := key["secret_key"]
 	if !ok {
 		return "", "", err
 	}
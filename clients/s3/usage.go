@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// BucketUsage is the storage used by a bucket
+type BucketUsage struct {
+	SizeBytes   int64
+	ObjectCount int64
+	// Truncated is set if the bucket contains more objects than were
+	// listed
+	Truncated bool
+}
+
+// GetBucketUsage measures the usage of the bucket by listing at most limit
+// objects
+func (c *Client) GetBucketUsage(ctx context.Context, userID, bucketName, region string, limit int64) (*BucketUsage, error) {
+	s3Client, err := c.getBucketS3Client(ctx, userID, bucketName, region)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &BucketUsage{}
+	lreq := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+	}
+	err = s3Client.ListObjectsV2PagesWithContext(ctx, lreq, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			if usage.ObjectCount >= limit {
+				usage.Truncated = true
+				return false
+			}
+			usage.ObjectCount++
+			usage.SizeBytes += aws.Int64Value(o.Size)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+)
+
+const errCodeNoSuchWebsiteConfiguration = "NoSuchWebsiteConfiguration"
+
+// SetBucketWebsite replaces the website configuration of the bucket. The
+// configuration is removed if website is nil.
+func (c *Client) SetBucketWebsite(ctx context.Context, userID, bucketName, region string, website *storagev1alpha1.WebsiteConfiguration) error {
+	s3Client, err := c.getBucketS3Client(ctx, userID, bucketName, region)
+	if err != nil {
+		return err
+	}
+
+	if website == nil {
+		dreq := &s3.DeleteBucketWebsiteInput{
+			Bucket: aws.String(bucketName),
+		}
+		_, err = s3Client.DeleteBucketWebsiteWithContext(ctx, dreq)
+		return err
+	}
+
+	wreq := &s3.PutBucketWebsiteInput{
+		Bucket:               aws.String(bucketName),
+		WebsiteConfiguration: generateWebsiteConfiguration(website),
+	}
+	_, err = s3Client.PutBucketWebsiteWithContext(ctx, wreq)
+	return err
+}
+
+func getBucketWebsite(ctx context.Context, s3Client *s3.S3, bucketName string) (*storagev1alpha1.WebsiteConfiguration, error) {
+	wreq := &s3.GetBucketWebsiteInput{
+		Bucket: aws.String(bucketName),
+	}
+	website, err := s3Client.GetBucketWebsiteWithContext(ctx, wreq)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == errCodeNoSuchWebsiteConfiguration {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return observeWebsiteConfiguration(website), nil
+}
+
+func generateWebsiteConfiguration(website *storagev1alpha1.WebsiteConfiguration) *s3.WebsiteConfiguration {
+	wc := &s3.WebsiteConfiguration{
+		IndexDocument: &s3.IndexDocument{Suffix: aws.String(website.IndexDocument)},
+	}
+	if website.ErrorDocument != "" {
+		wc.ErrorDocument = &s3.ErrorDocument{Key: aws.String(website.ErrorDocument)}
+	}
+	for _, r := range website.RoutingRules {
+		rule := &s3.RoutingRule{
+			Redirect: &s3.Redirect{
+				HostName:             optionalString(r.Redirect.HostName),
+				HttpRedirectCode:     optionalString(r.Redirect.HTTPRedirectCode),
+				Protocol:             optionalString(r.Redirect.Protocol),
+				ReplaceKeyPrefixWith: optionalString(r.Redirect.ReplaceKeyPrefixWith),
+				ReplaceKeyWith:       optionalString(r.Redirect.ReplaceKeyWith),
+			},
+		}
+		if r.Condition != nil {
+			rule.Condition = &s3.Condition{
+				KeyPrefixEquals:             optionalString(r.Condition.KeyPrefixEquals),
+				HttpErrorCodeReturnedEquals: optionalString(r.Condition.HTTPErrorCodeReturnedEquals),
+			}
+		}
+		wc.RoutingRules = append(wc.RoutingRules, rule)
+	}
+	return wc
+}
+
+func observeWebsiteConfiguration(website *s3.GetBucketWebsiteOutput) *storagev1alpha1.WebsiteConfiguration {
+	wc := &storagev1alpha1.WebsiteConfiguration{}
+	if website.IndexDocument != nil {
+		wc.IndexDocument = aws.StringValue(website.IndexDocument.Suffix)
+	}
+	if website.ErrorDocument != nil {
+		wc.ErrorDocument = aws.StringValue(website.ErrorDocument.Key)
+	}
+	for _, r := range website.RoutingRules {
+		rule := storagev1alpha1.RoutingRule{}
+		if r.Redirect != nil {
+			rule.Redirect = storagev1alpha1.RoutingRuleRedirect{
+				HostName:             aws.StringValue(r.Redirect.HostName),
+				HTTPRedirectCode:     aws.StringValue(r.Redirect.HttpRedirectCode),
+				Protocol:             aws.StringValue(r.Redirect.Protocol),
+				ReplaceKeyPrefixWith: aws.StringValue(r.Redirect.ReplaceKeyPrefixWith),
+				ReplaceKeyWith:       aws.StringValue(r.Redirect.ReplaceKeyWith),
+			}
+		}
+		if r.Condition != nil {
+			rule.Condition = &storagev1alpha1.RoutingRuleCondition{
+				KeyPrefixEquals:             aws.StringValue(r.Condition.KeyPrefixEquals),
+				HTTPErrorCodeReturnedEquals: aws.StringValue(r.Condition.HttpErrorCodeReturnedEquals),
+			}
+		}
+		wc.RoutingRules = append(wc.RoutingRules, rule)
+	}
+	return wc
+}
+
+// optionalString returns nil for empty strings so they are omitted from
+// requests
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
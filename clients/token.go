@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+
+	cloudscalev1alpha1 "github.com/vshn/stack-cloudscale/api/v1alpha1"
+)
+
+// nilUUID never identifies a Cloudscale resource.
+const nilUUID = "00000000-0000-0000-0000-000000000000"
+
+// VerifyToken verifies the Cloudscale API token by listing the flavors, which
+// is cheap and allowed for every valid token.
+//
+// The scope of the token is probed by deliberately deleting the server group
+// with the nil UUID. The server group can't exist, so the probe never changes
+// the account: a read-only token is forbidden to delete it, while a
+// read-write token is told it doesn't exist. Any other response leaves the
+// scope unknown.
+func VerifyToken(ctx context.Context, cfg *ProviderConfig, httpClient *http.Client) (cloudscalev1alpha1.TokenScope, error) {
+	c := NewCloudscaleClient(cfg, httpClient)
+
+	if err := Do(ctx, c, http.MethodGet, "v1/flavors", nil, nil); err != nil {
+		return "", errors.Wrap(err, "cannot verify token")
+	}
+
+	err := Do(ctx, c, http.MethodDelete, "v1/server-groups/"+nilUUID, nil, nil)
+	if errResp, ok := err.(*cloudscale.ErrorResponse); ok {
+		switch errResp.StatusCode {
+		case http.StatusForbidden:
+			return cloudscalev1alpha1.TokenScopeReadOnly, nil
+		case http.StatusNotFound:
+			return cloudscalev1alpha1.TokenScopeReadWrite, nil
+		}
+	}
+	return cloudscalev1alpha1.TokenScopeUnknown, nil
+}
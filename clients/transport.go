@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// HTTPOptions configure the HTTP clients of the Cloudscale API.
+type HTTPOptions struct {
+	// Timeout of a request, including its retries.
+	Timeout time.Duration
+
+	// MaxRetries is the maximum number of times a request is retried.
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry. The delay is doubled
+	// with every retry, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RateLimit is the number of requests per second sent with the same
+	// token, with bursts of up to Burst requests.
+	RateLimit float64
+	Burst     int
+}
+
+// DefaultHTTPOptions are the HTTP options used unless configured otherwise.
+var DefaultHTTPOptions = HTTPOptions{
+	Timeout:    2 * time.Minute,
+	MaxRetries: 5,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+	RateLimit:  5,
+	Burst:      10,
+}
+
+var (
+	httpOptions = DefaultHTTPOptions
+
+	limitersMu sync.Mutex
+	limiters   = map[[sha256.Size]byte]*rate.Limiter{}
+)
+
+var (
+	retriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloudscale_client_retries_total",
+		Help: "Total number of retried requests to the Cloudscale API by method and reason.",
+	}, []string{"method", "reason"})
+
+	throttledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cloudscale_client_throttled_total",
+		Help: "Total number of requests to the Cloudscale API delayed by the client rate limiter or rejected by the server with 429.",
+	}, []string{"source"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(retriesTotal, throttledTotal)
+}
+
+// SetHTTPOptions configures the HTTP clients created afterwards. It is meant
+// to be called once on startup.
+func SetHTTPOptions(o HTTPOptions) {
+	httpOptions = o
+}
+
+// NewHTTPClient returns an HTTP client for requests with the token. Requests
+// are rate limited per token and failed requests are retried with
+// exponential backoff.
+func NewHTTPClient(token string) *http.Client {
+	return &http.Client{
+		Timeout: httpOptions.Timeout,
+		Transport: &retryTransport{
+			base:    http.DefaultTransport,
+			limiter: limiterFor(token),
+			options: httpOptions,
+		},
+	}
+}
+
+// limiterFor returns the rate limiter shared by all clients of the token. The
+// token is only kept hashed.
+func limiterFor(token string) *rate.Limiter {
+	key := sha256.Sum256([]byte(token))
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[key]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(httpOptions.RateLimit), httpOptions.Burst)
+		limiters[key] = l
+	}
+	return l
+}
+
+// retryTransport is an http.RoundTripper which rate limits requests and
+// retries them on throttling and server errors. Requests which aren't
+// idempotent are only retried if the server throttled them, as they weren't
+// processed in that case.
+type retryTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+	options HTTPOptions
+}
+
+// RoundTrip sends the request, retrying it if it failed temporarily.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+		resp, err := t.base.RoundTrip(r)
+
+		reason, retry := retryReason(req, resp, err)
+		if !retry || attempt >= t.options.MaxRetries {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				throttledTotal.WithLabelValues("server").Inc()
+			}
+			if d, ok := retryAfter(resp); ok && d > delay {
+				delay = d
+			}
+			// Drain the body to reuse the connection.
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		retriesTotal.WithLabelValues(req.Method, reason).Inc()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// wait blocks until the rate limiter allows the next request.
+func (t *retryTransport) wait(ctx context.Context) error {
+	if t.limiter.Allow() {
+		return nil
+	}
+	throttledTotal.WithLabelValues("client").Inc()
+	return t.limiter.Wait(ctx)
+}
+
+// backoff returns the delay before the retry after the attempt: the minimum
+// backoff doubled for every attempt and capped at the maximum, with half of
+// it randomized.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.options.MaxBackoff
+	if attempt < 30 {
+		if b := t.options.MinBackoff << uint(attempt); b > 0 && b < d {
+			d = b
+		}
+	}
+	if d <= 1 {
+		return d
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
+
+// retryReason returns the reason to retry the request, if it should be
+// retried.
+func retryReason(req *http.Request, resp *http.Response, err error) (string, bool) {
+	if req.Body != nil && req.GetBody == nil {
+		// The body can't be sent again.
+		return "", false
+	}
+	if err != nil {
+		if req.Context().Err() != nil {
+			return "", false
+		}
+		return "error", idempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return strconv.Itoa(resp.StatusCode), true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return strconv.Itoa(resp.StatusCode), idempotent(req.Method)
+	}
+	return "", false
+}
+
+// idempotent returns true if sending a request with the method more than once
+// has the same effect as sending it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the
+// response, given either in seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
@@ -0,0 +1,334 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+)
+
+func TestRetryReason(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := map[string]struct {
+		method    string
+		body      bool
+		noGetBody bool
+		ctx       context.Context
+		status    int
+		err       error
+		reason    string
+		retry     bool
+	}{
+		"GETOK":                   {method: http.MethodGet, status: http.StatusOK},
+		"GETNotFound":             {method: http.MethodGet, status: http.StatusNotFound},
+		"GETTooManyRequests":      {method: http.MethodGet, status: http.StatusTooManyRequests, reason: "429", retry: true},
+		"POSTTooManyRequests":     {method: http.MethodPost, body: true, status: http.StatusTooManyRequests, reason: "429", retry: true},
+		"PATCHTooManyRequests":    {method: http.MethodPatch, body: true, status: http.StatusTooManyRequests, reason: "429", retry: true},
+		"GETInternalServerError":  {method: http.MethodGet, status: http.StatusInternalServerError, reason: "500", retry: true},
+		"GETBadGateway":           {method: http.MethodGet, status: http.StatusBadGateway, reason: "502", retry: true},
+		"PUTServiceUnavailable":   {method: http.MethodPut, body: true, status: http.StatusServiceUnavailable, reason: "503", retry: true},
+		"DELETEGatewayTimeout":    {method: http.MethodDelete, status: http.StatusGatewayTimeout, reason: "504", retry: true},
+		"GETNotImplemented":       {method: http.MethodGet, status: http.StatusNotImplemented},
+		"POSTBadGateway":          {method: http.MethodPost, body: true, status: http.StatusBadGateway, reason: "502"},
+		"PATCHServiceUnavailable": {method: http.MethodPatch, body: true, status: http.StatusServiceUnavailable, reason: "503"},
+		"GETError":                {method: http.MethodGet, err: errors.New("connection reset"), reason: "error", retry: true},
+		"POSTError":               {method: http.MethodPost, body: true, err: errors.New("connection reset"), reason: "error"},
+		"GETErrorCanceled":        {method: http.MethodGet, ctx: canceled, err: context.Canceled},
+		"PUTBodyNotReplayable":    {method: http.MethodPut, body: true, noGetBody: true, status: http.StatusServiceUnavailable},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			req, err := http.NewRequest(tc.method, "http://localhost/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req = req.WithContext(ctx)
+			if tc.body {
+				req.Body = ioutil.NopCloser(strings.NewReader("{}"))
+				req.GetBody = func() (io.ReadCloser, error) {
+					return ioutil.NopCloser(strings.NewReader("{}")), nil
+				}
+			}
+			if tc.noGetBody {
+				req.GetBody = nil
+			}
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status, Header: http.Header{}}
+			}
+
+			reason, retry := retryReason(req, resp, tc.err)
+			if retry != tc.retry {
+				t.Errorf("retryReason(...): want retry %t, got %t", tc.retry, retry)
+			}
+			if retry && reason != tc.reason {
+				t.Errorf("retryReason(...): want reason %q, got %q", tc.reason, reason)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tr := &retryTransport{options: HTTPOptions{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: 1 * time.Second,
+	}}
+
+	cases := map[string]struct {
+		attempt int
+		max     time.Duration
+	}{
+		"FirstRetry":  {attempt: 0, max: 100 * time.Millisecond},
+		"SecondRetry": {attempt: 1, max: 200 * time.Millisecond},
+		"FourthRetry": {attempt: 3, max: 800 * time.Millisecond},
+		"Capped":      {attempt: 4, max: 1 * time.Second},
+		"Overflow":    {attempt: 100, max: 1 * time.Second},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			seen := map[time.Duration]bool{}
+			for i := 0; i < 100; i++ {
+				d := tr.backoff(tc.attempt)
+				if d < tc.max/2 || d >= tc.max {
+					t.Fatalf("backoff(%d): want delay in [%s, %s), got %s", tc.attempt, tc.max/2, tc.max, d)
+				}
+				seen[d] = true
+			}
+			// Half of the delay is randomized.
+			if len(seen) < 2 {
+				t.Errorf("backoff(%d): want jittered delays, got %d distinct delays", tc.attempt, len(seen))
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		min    time.Duration
+		max    time.Duration
+		ok     bool
+	}{
+		"Missing":  {},
+		"Seconds":  {header: "3", min: 3 * time.Second, max: 3 * time.Second, ok: true},
+		"Zero":     {header: "0", ok: true},
+		"Negative": {header: "-1"},
+		"Invalid":  {header: "soon"},
+		// HTTP dates have a resolution of one second.
+		"Date": {header: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), min: 8 * time.Second, max: 10 * time.Second, ok: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+
+			d, ok := retryAfter(resp)
+			if ok != tc.ok {
+				t.Fatalf("retryAfter(%q): want ok %t, got %t", tc.header, tc.ok, ok)
+			}
+			if d < tc.min || d > tc.max {
+				t.Errorf("retryAfter(%q): want delay in [%s, %s], got %s", tc.header, tc.min, tc.max, d)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	type response struct {
+		status     int
+		retryAfter string
+	}
+
+	cases := map[string]struct {
+		method     string
+		body       string
+		responses  []response
+		status     int
+		attempts   int32
+		minElapsed time.Duration
+		retries    map[string]float64
+		throttled  float64
+	}{
+		"GETSucceeds": {
+			method:    http.MethodGet,
+			responses: []response{{status: http.StatusOK}},
+			status:    http.StatusOK,
+			attempts:  1,
+		},
+		"GETRetriedOnServiceUnavailable": {
+			method:    http.MethodGet,
+			responses: []response{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			status:    http.StatusOK,
+			attempts:  2,
+			retries:   map[string]float64{"503": 1},
+		},
+		"GETGivesUpAfterMaxRetries": {
+			method:    http.MethodGet,
+			responses: []response{{status: http.StatusBadGateway}},
+			status:    http.StatusBadGateway,
+			attempts:  3,
+			retries:   map[string]float64{"502": 2},
+		},
+		"POSTNotRetriedOnBadGateway": {
+			method:    http.MethodPost,
+			body:      `{"name":"test"}`,
+			responses: []response{{status: http.StatusBadGateway}, {status: http.StatusCreated}},
+			status:    http.StatusBadGateway,
+			attempts:  1,
+			retries:   map[string]float64{"502": 0},
+		},
+		"POSTRetriedOnTooManyRequests": {
+			method:    http.MethodPost,
+			body:      `{"name":"test"}`,
+			responses: []response{{status: http.StatusTooManyRequests}, {status: http.StatusCreated}},
+			status:    http.StatusCreated,
+			attempts:  2,
+			retries:   map[string]float64{"429": 1},
+			throttled: 1,
+		},
+		"TooManyRequestsHonoursRetryAfter": {
+			method:     http.MethodGet,
+			responses:  []response{{status: http.StatusTooManyRequests, retryAfter: "1"}, {status: http.StatusOK}},
+			status:     http.StatusOK,
+			attempts:   2,
+			minElapsed: 1 * time.Second,
+			retries:    map[string]float64{"429": 1},
+			throttled:  1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				// Retried requests must be sent with their full body.
+				if b, _ := ioutil.ReadAll(r.Body); string(b) != tc.body {
+					t.Errorf("attempt %d: want body %q, got %q", n, tc.body, string(b))
+				}
+				resp := tc.responses[len(tc.responses)-1]
+				if int(n) <= len(tc.responses) {
+					resp = tc.responses[n-1]
+				}
+				if resp.retryAfter != "" {
+					w.Header().Set("Retry-After", resp.retryAfter)
+				}
+				w.WriteHeader(resp.status)
+			}))
+			defer srv.Close()
+
+			retriesBefore := map[string]float64{}
+			for reason := range tc.retries {
+				retriesBefore[reason] = testutil.ToFloat64(retriesTotal.WithLabelValues(tc.method, reason))
+			}
+			throttledBefore := testutil.ToFloat64(throttledTotal.WithLabelValues("server"))
+
+			c := &http.Client{Transport: &retryTransport{
+				base:    http.DefaultTransport,
+				limiter: rate.NewLimiter(rate.Inf, 1),
+				options: HTTPOptions{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			}}
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			elapsed := time.Since(start)
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("want status %d, got %d", tc.status, resp.StatusCode)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tc.attempts {
+				t.Errorf("want %d attempts, got %d", tc.attempts, got)
+			}
+			if elapsed < tc.minElapsed {
+				t.Errorf("want request to take at least %s, took %s", tc.minElapsed, elapsed)
+			}
+			for reason, want := range tc.retries {
+				if got := testutil.ToFloat64(retriesTotal.WithLabelValues(tc.method, reason)) - retriesBefore[reason]; got != want {
+					t.Errorf("cloudscale_client_retries_total{method=%q,reason=%q}: want %v more, got %v", tc.method, reason, want, got)
+				}
+			}
+			if got := testutil.ToFloat64(throttledTotal.WithLabelValues("server")) - throttledBefore; got != tc.throttled {
+				t.Errorf("cloudscale_client_throttled_total{source=\"server\"}: want %v more, got %v", tc.throttled, got)
+			}
+		})
+	}
+}
+
+func TestLimiterFor(t *testing.T) {
+	a := limiterFor("token-a")
+	if limiterFor("token-a") != a {
+		t.Error("limiterFor(...): want the same limiter for the same token")
+	}
+	if limiterFor("token-b") == a {
+		t.Error("limiterFor(...): want different limiters for different tokens")
+	}
+}
+
+func TestWait(t *testing.T) {
+	// One request every 50ms, without bursts.
+	tr := &retryTransport{limiter: rate.NewLimiter(rate.Every(50*time.Millisecond), 1)}
+	before := testutil.ToFloat64(throttledTotal.WithLabelValues("client"))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := tr.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("wait(...): want requests to be delayed by the limiter, took %s", elapsed)
+	}
+	if got := testutil.ToFloat64(throttledTotal.WithLabelValues("client")) - before; got != 2 {
+		t.Errorf("cloudscale_client_throttled_total{source=\"client\"}: want 2 more, got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := tr.wait(ctx); err == nil {
+		t.Error("wait(...): want an error for a canceled context")
+	}
+}
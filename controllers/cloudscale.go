@@ -19,6 +19,9 @@ package controllers
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/vshn/stack-cloudscale/controllers/compute"
+	"github.com/vshn/stack-cloudscale/controllers/network"
+	"github.com/vshn/stack-cloudscale/controllers/provider"
 	"github.com/vshn/stack-cloudscale/controllers/s3"
 )
 
@@ -27,10 +30,25 @@ func SetupWithManager(mgr ctrl.Manager) error {
 	controllers := []interface {
 		SetupWithManager(ctrl.Manager) error
 	}{
+		&provider.Controller{},
 		&s3.BucketClaimSchedulingController{},
 		&s3.BucketClaimDefaultingController{},
 		&s3.BucketClaimController{},
 		&s3.BucketController{},
+		&s3.ObjectsUserController{},
+		&compute.ServerController{},
+		&compute.VolumeController{},
+		&compute.ServerGroupController{},
+		&compute.CustomImageController{},
+		&compute.VolumeSnapshotController{},
+		&network.NetworkController{},
+		&network.SubnetController{},
+		&network.FloatingIPController{},
+		&network.LoadBalancerController{},
+		&network.LoadBalancerPoolController{},
+		&network.LoadBalancerPoolMemberController{},
+		&network.LoadBalancerListenerController{},
+		&network.LoadBalancerHealthMonitorController{},
 	}
 
 	for _, c := range controllers {
@@ -0,0 +1,260 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/compute"
+)
+
+const (
+	errNotCustomImage = "managed resource is not a CustomImage"
+	errImportPending  = "cannot delete custom image before its import finished"
+
+	msgImportFailed = "custom image import failed, delete the CustomImage to import it again"
+
+	defaultUserDataHandling = "pass-through"
+)
+
+// Reasons of a custom image which is not available because of its import.
+const (
+	reasonImportInProgress runtimev1alpha1.ConditionReason = "Image import in progress"
+	reasonImportFailed     runtimev1alpha1.ConditionReason = "Image import failed"
+)
+
+// CustomImageController is responsible for adding the CustomImage controller
+// and its corresponding reconciler to the manager with any runtime
+// configuration.
+type CustomImageController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile CustomImages using an ExternalClient produced by
+// customImageConnecter, which satisfies the ExternalConnecter interface.
+func (r *CustomImageController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(computev1alpha1.CustomImageKindAPIVersion)).
+		For(&computev1alpha1.CustomImage{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(computev1alpha1.CustomImageGroupVersionKind),
+			resource.WithExternalConnecter(&customImageConnecter{client: mgr.GetClient(), newComputeClient: compute.NewClient})))
+}
+
+// customImageConnecter satisfies the resource.ExternalConnecter interface.
+type customImageConnecter struct {
+	client           client.Client
+	newComputeClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) compute.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a CustomImage) by
+// using the Provider it references to create a new compute client.
+func (c *customImageConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	i, ok := mg.(*computev1alpha1.CustomImage)
+	if !ok {
+		return nil, errors.New(errNotCustomImage)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, i.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &customImageExternal{computeClient: c.newComputeClient(ctx, cfg, nil)}, nil
+}
+
+type customImageExternal struct {
+	computeClient compute.Service
+}
+
+// Observe the import of the custom image and the image once it is imported.
+// An image which is still imported or whose import failed is reported as up
+// to date, as there is nothing to update. A failed import is reported by the
+// condition of the CustomImage and no longer exists once it is deleted, as
+// there is nothing to delete.
+func (e *customImageExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	i, ok := mg.(*computev1alpha1.CustomImage)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotCustomImage)
+	}
+	log.Info("Observe", "customImage", i.Name)
+
+	o := &i.Status.AtProvider
+	if o.UUID == "" && o.ImportUUID != "" {
+		imp, err := e.computeClient.GetCustomImageImport(ctx, o.ImportUUID)
+		if clients.IsErrorNotFound(err) {
+			return resource.ExternalObservation{ResourceExists: false}, nil
+		}
+		if err != nil {
+			return resource.ExternalObservation{}, errors.Wrap(err, "cannot get custom image import")
+		}
+		o.ImportStatus = imp.Status
+
+		switch imp.Status {
+		case compute.ImportSuccess:
+			o.UUID = imp.CustomImage.UUID
+		case compute.ImportFailed:
+			if i.GetDeletionTimestamp() != nil {
+				return resource.ExternalObservation{ResourceExists: false}, nil
+			}
+			i.SetConditions(importCondition(reasonImportFailed, fmt.Sprintf("%s: %s", msgImportFailed, imp.ErrorMessage)))
+			return resource.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		default:
+			i.SetConditions(importCondition(reasonImportInProgress, fmt.Sprintf("importing %s, the import is %s", imp.URL, imp.Status)))
+			return resource.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
+
+	image, err := e.computeClient.GetCustomImage(ctx, o.UUID, meta.GetExternalName(i))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get custom image")
+	}
+
+	o.UUID = image.UUID
+	o.SizeGB = image.SizeGB
+	o.Zones = nil
+	for _, z := range image.Zones {
+		o.Zones = append(o.Zones, z.Slug)
+	}
+	i.SetConditions(runtimev1alpha1.Available())
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: customImageUpToDate(meta.GetExternalName(i), i.Spec.ForProvider, image),
+	}, nil
+}
+
+// Create starts the import of the custom image.
+func (e *customImageExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	i, ok := mg.(*computev1alpha1.CustomImage)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotCustomImage)
+	}
+	log.Info("Create", "customImage", i.Name)
+
+	p := i.Spec.ForProvider
+	req := &compute.CustomImageImportRequest{
+		URL:              p.URL,
+		Name:             meta.GetExternalName(i),
+		Slug:             p.Slug,
+		UserDataHandling: p.UserDataHandling,
+		FirmwareType:     p.FirmwareType,
+		Zones:            p.Zones,
+		SourceFormat:     p.SourceFormat,
+	}
+	imp, err := e.computeClient.ImportCustomImage(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot import custom image")
+	}
+	i.Status.AtProvider.UUID = ""
+	i.Status.AtProvider.ImportUUID = imp.UUID
+	i.Status.AtProvider.ImportStatus = imp.Status
+	i.SetConditions(importCondition(reasonImportInProgress, fmt.Sprintf("importing %s, the import is %s", imp.URL, imp.Status)))
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name, slug and user data handling of the imported image. There
+// is nothing to update before the image is imported.
+func (e *customImageExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	i, ok := mg.(*computev1alpha1.CustomImage)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotCustomImage)
+	}
+	log.Info("Update", "customImage", i.Name)
+
+	if i.Status.AtProvider.UUID == "" {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	p := i.Spec.ForProvider
+	req := &compute.CustomImageUpdateRequest{
+		Name:             meta.GetExternalName(i),
+		Slug:             p.Slug,
+		UserDataHandling: userDataHandling(p),
+	}
+	err := e.computeClient.UpdateCustomImage(ctx, i.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update custom image")
+}
+
+// Delete the custom image. An image which is still imported can't be deleted
+// yet, the deletion is retried until the import succeeded or failed.
+func (e *customImageExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	i, ok := mg.(*computev1alpha1.CustomImage)
+	if !ok {
+		return errors.New(errNotCustomImage)
+	}
+	log.Info("Delete", "customImage", i.Name)
+	i.SetConditions(runtimev1alpha1.Deleting())
+
+	o := i.Status.AtProvider
+	if o.UUID == "" {
+		if o.ImportUUID != "" && o.ImportStatus != compute.ImportFailed {
+			return errors.New(errImportPending)
+		}
+		return nil
+	}
+	err := e.computeClient.DeleteCustomImage(ctx, i.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete custom image")
+	}
+	return nil
+}
+
+// customImageUpToDate returns true if the name, slug and user data handling
+// of the image match the desired parameters. The other parameters can't be
+// changed after the import.
+func customImageUpToDate(name string, p computev1alpha1.CustomImageParameters, image *compute.CustomImage) bool {
+	if image.Name != name || image.UserDataHandling != userDataHandling(p) {
+		return false
+	}
+	return p.Slug == "" || p.Slug == image.Slug
+}
+
+func userDataHandling(p computev1alpha1.CustomImageParameters) string {
+	if p.UserDataHandling == "" {
+		return defaultUserDataHandling
+	}
+	return p.UserDataHandling
+}
+
+// importCondition returns a condition that indicates the custom image is not
+// available because of its import.
+func importCondition(reason runtimev1alpha1.ConditionReason, message string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
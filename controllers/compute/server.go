@@ -0,0 +1,325 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/compute"
+)
+
+const (
+	errNotServer = "managed resource is not a Server"
+
+	interfaceTypePublic = "public"
+	defaultSSHUser      = "root"
+
+	resourceCredentialsSecretIPv6 = "ipv6"
+)
+
+// sshUsers are the default users of the images, by the prefix of the image
+// slug.
+var sshUsers = map[string]string{
+	"centos":  "centos",
+	"coreos":  "core",
+	"debian":  "debian",
+	"fedora":  "fedora",
+	"flatcar": "core",
+	"ubuntu":  "ubuntu",
+}
+
+var log = logging.Logger.WithName("compute_controller")
+
+// ServerController is responsible for adding the Server controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type ServerController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile Servers using an ExternalClient produced by
+// serverConnecter, which satisfies the ExternalConnecter interface.
+func (r *ServerController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(computev1alpha1.ServerKindAPIVersion)).
+		For(&computev1alpha1.Server{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(computev1alpha1.ServerGroupVersionKind),
+			resource.WithExternalConnecter(&serverConnecter{client: mgr.GetClient(), newComputeClient: compute.NewClient})))
+}
+
+// serverConnecter satisfies the resource.ExternalConnecter interface.
+type serverConnecter struct {
+	client           client.Client
+	newComputeClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) compute.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a Server) by using
+// the Provider it references to create a new compute client.
+func (c *serverConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*computev1alpha1.Server)
+	if !ok {
+		return nil, errors.New(errNotServer)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, s.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &serverExternal{kube: c.client, computeClient: c.newComputeClient(ctx, cfg, nil)}, nil
+}
+
+type serverExternal struct {
+	kube          client.Client
+	computeClient compute.Service
+}
+
+// Observe the existing server, if any.
+func (e *serverExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*computev1alpha1.Server)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotServer)
+	}
+	log.Info("Observe", "server", s.Name)
+
+	server, err := e.computeClient.GetServer(ctx, s.Status.AtProvider.UUID, meta.GetExternalName(s))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get server")
+	}
+
+	observeServer(s, server)
+	switch server.Status {
+	case cloudscale.ServerRunning:
+		s.SetConditions(runtimev1alpha1.Available())
+	case cloudscale.ServerStopped:
+		s.SetConditions(runtimev1alpha1.Unavailable())
+	default:
+		s.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	o := resource.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  server.Name == meta.GetExternalName(s) && server.Flavor.Slug == s.Spec.ForProvider.Flavor,
+		ConnectionDetails: serverConnectionDetails(s),
+	}
+	return o, nil
+}
+
+// Create a new server.
+func (e *serverExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*computev1alpha1.Server)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotServer)
+	}
+	log.Info("Create", "server", s.Name)
+	s.SetConditions(runtimev1alpha1.Creating())
+
+	serverGroups, err := e.resolveServerGroups(ctx, s)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	req := serverRequest(meta.GetExternalName(s), s.Spec.ForProvider)
+	req.ServerGroups = serverGroups
+	server, err := e.computeClient.CreateServer(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create server")
+	}
+	observeServer(s, server)
+	return resource.ExternalCreation{ConnectionDetails: serverConnectionDetails(s)}, nil
+}
+
+// Update the name and flavor of the server. The flavor can only be changed
+// while the server is stopped, so the server is stopped first and started
+// again once the flavor is changed.
+func (e *serverExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	s, ok := mg.(*computev1alpha1.Server)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotServer)
+	}
+	log.Info("Update", "server", s.Name)
+
+	server, err := e.computeClient.GetServer(ctx, s.Status.AtProvider.UUID, meta.GetExternalName(s))
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, "cannot get server")
+	}
+
+	req := &cloudscale.ServerUpdateRequest{}
+	if server.Name != meta.GetExternalName(s) {
+		req.Name = meta.GetExternalName(s)
+	}
+	if server.Flavor.Slug != s.Spec.ForProvider.Flavor {
+		// The server is stopped asynchronously, the flavor is changed by a
+		// later update.
+		if server.Status != cloudscale.ServerStopped {
+			req.Status = cloudscale.ServerStopped
+			return resource.ExternalUpdate{}, errors.Wrap(e.computeClient.UpdateServer(ctx, server.UUID, req), "cannot stop server")
+		}
+		req.Flavor = s.Spec.ForProvider.Flavor
+	}
+	if err := e.computeClient.UpdateServer(ctx, server.UUID, req); err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update server")
+	}
+	if req.Flavor != "" {
+		start := &cloudscale.ServerUpdateRequest{Status: cloudscale.ServerRunning}
+		if err := e.computeClient.UpdateServer(ctx, server.UUID, start); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, "cannot start server")
+		}
+	}
+	return resource.ExternalUpdate{}, nil
+}
+
+// Delete the server and its root volume.
+func (e *serverExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*computev1alpha1.Server)
+	if !ok {
+		return errors.New(errNotServer)
+	}
+	log.Info("Delete", "server", s.Name)
+	s.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.computeClient.DeleteServer(ctx, s.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete server")
+	}
+	return nil
+}
+
+// resolveServerGroups returns the UUIDs of the server groups the server
+// joins, including the ones of the referenced ServerGroups.
+func (e *serverExternal) resolveServerGroups(ctx context.Context, s *computev1alpha1.Server) ([]string, error) {
+	uuids := append([]string{}, s.Spec.ForProvider.ServerGroups...)
+	for _, ref := range s.Spec.ForProvider.ServerGroupRefs {
+		g := &computev1alpha1.ServerGroup{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, g); err != nil {
+			return nil, errors.Wrap(err, "cannot get referenced ServerGroup")
+		}
+		if g.Status.AtProvider.UUID == "" {
+			return nil, errors.Errorf("referenced ServerGroup %s is not ready", ref.Name)
+		}
+		uuids = append(uuids, g.Status.AtProvider.UUID)
+	}
+	return uuids, nil
+}
+
+// serverRequest returns the request creating a server with the supplied
+// parameters. The server groups are resolved separately.
+func serverRequest(name string, p computev1alpha1.ServerParameters) *compute.ServerRequest {
+	req := &compute.ServerRequest{
+		ServerRequest: cloudscale.ServerRequest{
+			Name:     name,
+			Flavor:   p.Flavor,
+			Image:    p.Image,
+			Zone:     p.Zone,
+			SSHKeys:  p.SSHKeys,
+			UserData: p.UserData,
+		},
+	}
+	if req.SSHKeys == nil {
+		req.SSHKeys = []string{}
+	}
+	if p.VolumeSizeGB != nil {
+		req.VolumeSizeGB = *p.VolumeSizeGB
+	}
+	if len(p.Volumes) > 0 {
+		volumes := make([]cloudscale.Volume, 0, len(p.Volumes))
+		for _, v := range p.Volumes {
+			volumes = append(volumes, cloudscale.Volume{SizeGB: v.SizeGB, Type: v.Type})
+		}
+		req.Volumes = &volumes
+	}
+	for _, i := range p.Interfaces {
+		ireq := compute.InterfaceRequest{Network: i.Network}
+		for _, a := range i.Addresses {
+			ireq.Addresses = append(ireq.Addresses, compute.AddressRequest{Subnet: a.Subnet, Address: a.Address})
+		}
+		req.Interfaces = append(req.Interfaces, ireq)
+	}
+	return req
+}
+
+// observeServer updates the status of the Server with the observed server.
+func observeServer(s *computev1alpha1.Server, server *cloudscale.Server) {
+	o := &s.Status.AtProvider
+	o.UUID = server.UUID
+	o.State = server.Status
+	o.Zone = server.Zone.Slug
+	o.PublicIPv4 = ""
+	o.PublicIPv6 = ""
+	o.Interfaces = make([]computev1alpha1.ServerInterfaceObservation, 0, len(server.Interfaces))
+	for _, i := range server.Interfaces {
+		io := computev1alpha1.ServerInterfaceObservation{Type: i.Type}
+		for _, a := range i.Adresses {
+			io.Addresses = append(io.Addresses, a.Address)
+			if i.Type != interfaceTypePublic {
+				continue
+			}
+			if a.Version == 4 && o.PublicIPv4 == "" {
+				o.PublicIPv4 = a.Address
+			}
+			if a.Version == 6 && o.PublicIPv6 == "" {
+				o.PublicIPv6 = a.Address
+			}
+		}
+		o.Interfaces = append(o.Interfaces, io)
+	}
+}
+
+// serverConnectionDetails returns the public IP and the SSH user of the
+// server.
+func serverConnectionDetails(s *computev1alpha1.Server) resource.ConnectionDetails {
+	o := s.Status.AtProvider
+	endpoint := o.PublicIPv4
+	if endpoint == "" {
+		endpoint = o.PublicIPv6
+	}
+	return resource.ConnectionDetails{
+		runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(endpoint),
+		runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(sshUser(s.Spec.ForProvider)),
+		resourceCredentialsSecretIPv6:                        []byte(o.PublicIPv6),
+	}
+}
+
+// sshUser returns the SSH user of the server, derived from the image if it
+// isn't set explicitly.
+func sshUser(p computev1alpha1.ServerParameters) string {
+	if p.SSHUser != nil {
+		return *p.SSHUser
+	}
+	for prefix, user := range sshUsers {
+		if strings.HasPrefix(p.Image, prefix) {
+			return user
+		}
+	}
+	return defaultSSHUser
+}
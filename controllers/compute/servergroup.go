@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/compute"
+)
+
+const (
+	errNotServerGroup        = "managed resource is not a ServerGroup"
+	errServerGroupHasMembers = "server group still has members"
+
+	defaultServerGroupType = "anti-affinity"
+)
+
+// reasonServerGroupHasMembers is the reason of a server group which is not
+// deleted because it still has members.
+const reasonServerGroupHasMembers runtimev1alpha1.ConditionReason = "Server group still has members"
+
+// ServerGroupController is responsible for adding the ServerGroup controller
+// and its corresponding reconciler to the manager with any runtime
+// configuration.
+type ServerGroupController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile ServerGroups using an ExternalClient produced by
+// serverGroupConnecter, which satisfies the ExternalConnecter interface.
+func (r *ServerGroupController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(computev1alpha1.ServerGroupKindAPIVersion)).
+		For(&computev1alpha1.ServerGroup{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(computev1alpha1.ServerGroupGroupVersionKind),
+			resource.WithExternalConnecter(&serverGroupConnecter{client: mgr.GetClient(), newComputeClient: compute.NewClient})))
+}
+
+// serverGroupConnecter satisfies the resource.ExternalConnecter interface.
+type serverGroupConnecter struct {
+	client           client.Client
+	newComputeClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) compute.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a ServerGroup) by
+// using the Provider it references to create a new compute client.
+func (c *serverGroupConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	g, ok := mg.(*computev1alpha1.ServerGroup)
+	if !ok {
+		return nil, errors.New(errNotServerGroup)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, g.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &serverGroupExternal{computeClient: c.newComputeClient(ctx, cfg, nil)}, nil
+}
+
+type serverGroupExternal struct {
+	computeClient compute.Service
+}
+
+// Observe the existing server group, if any.
+func (e *serverGroupExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	g, ok := mg.(*computev1alpha1.ServerGroup)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotServerGroup)
+	}
+	log.Info("Observe", "serverGroup", g.Name)
+
+	group, err := e.computeClient.GetServerGroup(ctx, g.Status.AtProvider.UUID, meta.GetExternalName(g))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get server group")
+	}
+
+	observeServerGroup(g, group)
+	g.SetConditions(runtimev1alpha1.Available())
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: group.Name == meta.GetExternalName(g),
+	}
+	return o, nil
+}
+
+// Create a new server group.
+func (e *serverGroupExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	g, ok := mg.(*computev1alpha1.ServerGroup)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotServerGroup)
+	}
+	log.Info("Create", "serverGroup", g.Name)
+	g.SetConditions(runtimev1alpha1.Creating())
+
+	p := g.Spec.ForProvider
+	req := &cloudscale.ServerGroupRequest{
+		ZonalResourceRequest: cloudscale.ZonalResourceRequest{Zone: p.Zone},
+		Name:                 meta.GetExternalName(g),
+		Type:                 p.Type,
+	}
+	if req.Type == "" {
+		req.Type = defaultServerGroupType
+	}
+	group, err := e.computeClient.CreateServerGroup(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create server group")
+	}
+	observeServerGroup(g, group)
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name of the server group. The type and zone can't be changed.
+func (e *serverGroupExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	g, ok := mg.(*computev1alpha1.ServerGroup)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotServerGroup)
+	}
+	log.Info("Update", "serverGroup", g.Name)
+
+	req := &compute.ServerGroupUpdateRequest{Name: meta.GetExternalName(g)}
+	err := e.computeClient.UpdateServerGroup(ctx, g.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update server group")
+}
+
+// Delete the server group. Groups with members are not deleted, the finalizer
+// keeps the ServerGroup around until all member servers are deleted.
+func (e *serverGroupExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	g, ok := mg.(*computev1alpha1.ServerGroup)
+	if !ok {
+		return errors.New(errNotServerGroup)
+	}
+	log.Info("Delete", "serverGroup", g.Name)
+
+	group, err := e.computeClient.GetServerGroup(ctx, g.Status.AtProvider.UUID, meta.GetExternalName(g))
+	if clients.IsErrorNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot get server group")
+	}
+	observeServerGroup(g, group)
+	if len(group.Servers) > 0 {
+		g.SetConditions(serverGroupHasMembers(g.Status.AtProvider.Servers))
+		return errors.New(errServerGroupHasMembers)
+	}
+
+	g.SetConditions(runtimev1alpha1.Deleting())
+	err = e.computeClient.DeleteServerGroup(ctx, group.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete server group")
+	}
+	return nil
+}
+
+// observeServerGroup updates the status of the ServerGroup with the observed
+// server group.
+func observeServerGroup(g *computev1alpha1.ServerGroup, group *cloudscale.ServerGroup) {
+	o := &g.Status.AtProvider
+	o.UUID = group.UUID
+	o.Zone = group.Zone.Slug
+	o.Servers = nil
+	for _, s := range group.Servers {
+		o.Servers = append(o.Servers, s.UUID)
+	}
+}
+
+// serverGroupHasMembers returns a condition that indicates the server group
+// is not deleted because it still has members.
+func serverGroupHasMembers(servers []string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonServerGroupHasMembers,
+		Message:            fmt.Sprintf("the server group is deleted once its member servers are deleted: %s", strings.Join(servers, ", ")),
+	}
+}
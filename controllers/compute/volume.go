@@ -0,0 +1,275 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/compute"
+)
+
+const (
+	errNotVolume    = "managed resource is not a Volume"
+	errVolumeShrink = "volume cannot be shrunk"
+)
+
+// reasonVolumeShrink is the reason of a volume which is not resized because
+// it would shrink.
+const reasonVolumeShrink runtimev1alpha1.ConditionReason = "Volume cannot be shrunk"
+
+// VolumeController is responsible for adding the Volume controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type VolumeController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile Volumes using an ExternalClient produced by
+// volumeConnecter, which satisfies the ExternalConnecter interface.
+func (r *VolumeController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(computev1alpha1.VolumeKindAPIVersion)).
+		For(&computev1alpha1.Volume{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(computev1alpha1.VolumeGroupVersionKind),
+			resource.WithExternalConnecter(&volumeConnecter{client: mgr.GetClient(), newComputeClient: compute.NewClient})))
+}
+
+// volumeConnecter satisfies the resource.ExternalConnecter interface.
+type volumeConnecter struct {
+	client           client.Client
+	newComputeClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) compute.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a Volume) by using
+// the Provider it references to create a new compute client.
+func (c *volumeConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	v, ok := mg.(*computev1alpha1.Volume)
+	if !ok {
+		return nil, errors.New(errNotVolume)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, v.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &volumeExternal{kube: c.client, computeClient: c.newComputeClient(ctx, cfg, nil)}, nil
+}
+
+type volumeExternal struct {
+	kube          client.Client
+	computeClient compute.Service
+}
+
+// Observe the existing volume, if any.
+func (e *volumeExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	v, ok := mg.(*computev1alpha1.Volume)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotVolume)
+	}
+	log.Info("Observe", "volume", v.Name)
+
+	volume, err := e.computeClient.GetVolume(ctx, v.Status.AtProvider.UUID, meta.GetExternalName(v))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get volume")
+	}
+
+	observeVolume(v, volume)
+	v.SetConditions(runtimev1alpha1.Available())
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: volumeUpToDate(meta.GetExternalName(v), v.Spec.ForProvider, volume),
+	}
+	return o, nil
+}
+
+// Create a new volume, restored from a snapshot if one is set.
+func (e *volumeExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	v, ok := mg.(*computev1alpha1.Volume)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotVolume)
+	}
+	log.Info("Create", "volume", v.Name)
+	v.SetConditions(runtimev1alpha1.Creating())
+
+	snapshotUUID, err := e.resolveSnapshot(ctx, v)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	p := v.Spec.ForProvider
+	serverUUIDs := serverUUIDs(p)
+	req := &compute.VolumeRequest{
+		VolumeRequest: cloudscale.VolumeRequest{
+			ZonalResourceRequest: cloudscale.ZonalResourceRequest{Zone: p.Zone},
+			Name:                 meta.GetExternalName(v),
+			SizeGB:               p.SizeGB,
+			Type:                 p.Type,
+			ServerUUIDs:          &serverUUIDs,
+		},
+		VolumeSnapshotUUID: snapshotUUID,
+	}
+	volume, err := e.computeClient.CreateVolume(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create volume")
+	}
+	observeVolume(v, volume)
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name, size and attached servers of the volume. Volumes are only
+// grown, never shrunk.
+func (e *volumeExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	v, ok := mg.(*computev1alpha1.Volume)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotVolume)
+	}
+	log.Info("Update", "volume", v.Name)
+
+	p := v.Spec.ForProvider
+	if p.SizeGB < v.Status.AtProvider.SizeGB {
+		v.SetConditions(volumeShrink(p.SizeGB, v.Status.AtProvider.SizeGB))
+		return resource.ExternalUpdate{}, errors.New(errVolumeShrink)
+	}
+
+	serverUUIDs := serverUUIDs(p)
+	req := &cloudscale.VolumeRequest{
+		Name:        meta.GetExternalName(v),
+		SizeGB:      p.SizeGB,
+		ServerUUIDs: &serverUUIDs,
+	}
+	err := e.computeClient.UpdateVolume(ctx, v.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update volume")
+}
+
+// Delete the volume.
+func (e *volumeExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	v, ok := mg.(*computev1alpha1.Volume)
+	if !ok {
+		return errors.New(errNotVolume)
+	}
+	log.Info("Delete", "volume", v.Name)
+	v.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.computeClient.DeleteVolume(ctx, v.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete volume")
+	}
+	return nil
+}
+
+// resolveSnapshot returns the UUID of the snapshot the volume is restored
+// from, which is either set explicitly or resolved from the referenced
+// VolumeSnapshot. The referenced snapshot must be available.
+func (e *volumeExternal) resolveSnapshot(ctx context.Context, v *computev1alpha1.Volume) (string, error) {
+	ref := v.Spec.ForProvider.SnapshotRef
+	if ref == nil {
+		return v.Spec.ForProvider.SnapshotUUID, nil
+	}
+	s := &computev1alpha1.VolumeSnapshot{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, "cannot get referenced VolumeSnapshot")
+	}
+	if s.Status.AtProvider.UUID == "" || s.Status.AtProvider.State != compute.VolumeSnapshotStatusAvailable {
+		return "", errors.Errorf("referenced VolumeSnapshot %s is not ready", ref.Name)
+	}
+	return s.Status.AtProvider.UUID, nil
+}
+
+// observeVolume updates the status of the Volume with the observed volume.
+func observeVolume(v *computev1alpha1.Volume, volume *cloudscale.Volume) {
+	o := &v.Status.AtProvider
+	o.UUID = volume.UUID
+	o.SizeGB = volume.SizeGB
+	o.Type = volume.Type
+	o.Zone = volume.Zone.Slug
+	o.ServerUUIDs = nil
+	if volume.ServerUUIDs != nil {
+		o.ServerUUIDs = *volume.ServerUUIDs
+	}
+}
+
+// volumeUpToDate returns true if the observed volume matches the desired
+// parameters. The type and zone can't be changed and are ignored.
+func volumeUpToDate(name string, p computev1alpha1.VolumeParameters, volume *cloudscale.Volume) bool {
+	if volume.Name != name || volume.SizeGB != p.SizeGB {
+		return false
+	}
+	var observed []string
+	if volume.ServerUUIDs != nil {
+		observed = *volume.ServerUUIDs
+	}
+	return uuidsEqual(p.ServerUUIDs, observed)
+}
+
+// volumeShrink returns a condition that indicates the volume is not resized
+// because it would shrink.
+func volumeShrink(desired, observed int) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonVolumeShrink,
+		Message:            fmt.Sprintf("the volume has %d GB and cannot be shrunk to %d GB", observed, desired),
+	}
+}
+
+// serverUUIDs returns the UUIDs of the servers to attach the volume to. An
+// empty list detaches the volume from all servers.
+func serverUUIDs(p computev1alpha1.VolumeParameters) []string {
+	if p.ServerUUIDs == nil {
+		return []string{}
+	}
+	return p.ServerUUIDs
+}
+
+// uuidsEqual compares two lists of UUIDs regardless of their order.
+func uuidsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string{}, a...)
+	sb := append([]string{}, b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/compute"
+)
+
+const errNotVolumeSnapshot = "managed resource is not a VolumeSnapshot"
+
+// reasonSnapshotNotAvailable is the reason of a snapshot which is still
+// being taken or has failed.
+const reasonSnapshotNotAvailable runtimev1alpha1.ConditionReason = "Snapshot is not available"
+
+// VolumeSnapshotController is responsible for adding the VolumeSnapshot
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type VolumeSnapshotController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile VolumeSnapshots using an ExternalClient produced by
+// volumeSnapshotConnecter, which satisfies the ExternalConnecter interface.
+func (r *VolumeSnapshotController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(computev1alpha1.VolumeSnapshotKindAPIVersion)).
+		For(&computev1alpha1.VolumeSnapshot{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(computev1alpha1.VolumeSnapshotGroupVersionKind),
+			resource.WithExternalConnecter(&volumeSnapshotConnecter{client: mgr.GetClient(), newComputeClient: compute.NewClient})))
+}
+
+// volumeSnapshotConnecter satisfies the resource.ExternalConnecter interface.
+type volumeSnapshotConnecter struct {
+	client           client.Client
+	newComputeClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) compute.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a VolumeSnapshot)
+// by using the Provider it references to create a new compute client.
+func (c *volumeSnapshotConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*computev1alpha1.VolumeSnapshot)
+	if !ok {
+		return nil, errors.New(errNotVolumeSnapshot)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, s.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &volumeSnapshotExternal{computeClient: c.newComputeClient(ctx, cfg, nil)}, nil
+}
+
+type volumeSnapshotExternal struct {
+	computeClient compute.Service
+}
+
+// Observe the existing snapshot, if any. The snapshot is available once it
+// was taken.
+func (e *volumeSnapshotExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*computev1alpha1.VolumeSnapshot)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotVolumeSnapshot)
+	}
+	log.Info("Observe", "volumeSnapshot", s.Name)
+
+	snapshot, err := e.computeClient.GetVolumeSnapshot(ctx, s.Status.AtProvider.UUID, meta.GetExternalName(s))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get volume snapshot")
+	}
+
+	observeVolumeSnapshot(s, snapshot)
+	if snapshot.Status == compute.VolumeSnapshotStatusAvailable {
+		s.SetConditions(runtimev1alpha1.Available())
+	} else {
+		s.SetConditions(snapshotNotAvailable(snapshot.Status))
+	}
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: snapshot.Name == meta.GetExternalName(s),
+	}
+	return o, nil
+}
+
+// Create a new snapshot of the source volume.
+func (e *volumeSnapshotExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*computev1alpha1.VolumeSnapshot)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotVolumeSnapshot)
+	}
+	log.Info("Create", "volumeSnapshot", s.Name)
+	s.SetConditions(runtimev1alpha1.Creating())
+
+	req := &compute.VolumeSnapshotRequest{
+		Name:         meta.GetExternalName(s),
+		SourceVolume: s.Spec.ForProvider.SourceVolume,
+	}
+	snapshot, err := e.computeClient.CreateVolumeSnapshot(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create volume snapshot")
+	}
+	observeVolumeSnapshot(s, snapshot)
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name of the snapshot. A snapshot is never retaken, changing the
+// source volume has no effect.
+func (e *volumeSnapshotExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	s, ok := mg.(*computev1alpha1.VolumeSnapshot)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotVolumeSnapshot)
+	}
+	log.Info("Update", "volumeSnapshot", s.Name)
+
+	req := &compute.VolumeSnapshotRequest{
+		Name: meta.GetExternalName(s),
+	}
+	err := e.computeClient.UpdateVolumeSnapshot(ctx, s.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update volume snapshot")
+}
+
+// Delete the snapshot.
+func (e *volumeSnapshotExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*computev1alpha1.VolumeSnapshot)
+	if !ok {
+		return errors.New(errNotVolumeSnapshot)
+	}
+	log.Info("Delete", "volumeSnapshot", s.Name)
+	s.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.computeClient.DeleteVolumeSnapshot(ctx, s.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete volume snapshot")
+	}
+	return nil
+}
+
+// observeVolumeSnapshot updates the status of the VolumeSnapshot with the
+// observed snapshot.
+func observeVolumeSnapshot(s *computev1alpha1.VolumeSnapshot, snapshot *compute.VolumeSnapshot) {
+	o := &s.Status.AtProvider
+	o.UUID = snapshot.UUID
+	o.SourceVolumeUUID = snapshot.SourceVolume.UUID
+	o.Zone = snapshot.Zone.Slug
+	o.SizeGB = snapshot.SizeGB
+	o.State = snapshot.Status
+	o.CreatedAt = nil
+	if !snapshot.CreatedAt.IsZero() {
+		createdAt := metav1.NewTime(snapshot.CreatedAt)
+		o.CreatedAt = &createdAt
+	}
+}
+
+// snapshotNotAvailable returns a condition that indicates the snapshot can't
+// be restored yet.
+func snapshotNotAvailable(state string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonSnapshotNotAvailable,
+		Message:            fmt.Sprintf("the snapshot is %s", state),
+	}
+}
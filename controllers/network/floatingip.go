@@ -0,0 +1,259 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/vshn/stack-cloudscale/api/compute/v1alpha1"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const (
+	errNotFloatingIP = "managed resource is not a FloatingIP"
+
+	resourceCredentialsSecretNetwork = "network"
+)
+
+// FloatingIPController is responsible for adding the FloatingIP controller
+// and its corresponding reconciler to the manager with any runtime
+// configuration.
+type FloatingIPController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile FloatingIPs using an ExternalClient produced by
+// floatingIPConnecter, which satisfies the ExternalConnecter interface.
+func (r *FloatingIPController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.FloatingIPKindAPIVersion)).
+		For(&networkv1alpha1.FloatingIP{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.FloatingIPGroupVersionKind),
+			resource.WithExternalConnecter(&floatingIPConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// floatingIPConnecter satisfies the resource.ExternalConnecter interface.
+type floatingIPConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a FloatingIP) by
+// using the Provider it references to create a new network client.
+func (c *floatingIPConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	f, ok := mg.(*networkv1alpha1.FloatingIP)
+	if !ok {
+		return nil, errors.New(errNotFloatingIP)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, f.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &floatingIPExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type floatingIPExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing floating IP, if any. Floating IPs are only known by
+// their address, which is assigned when they are created and stored as their
+// external name. A floating IP whose Server can't be resolved is not up to
+// date, Update reports why.
+func (e *floatingIPExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	f, ok := mg.(*networkv1alpha1.FloatingIP)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotFloatingIP)
+	}
+	log.Info("Observe", "floatingIP", f.Name)
+
+	address := floatingIPAddress(f)
+	if address == "" {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	fip, err := e.networkClient.GetFloatingIP(ctx, address)
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get floating IP")
+	}
+
+	observeFloatingIP(f, fip)
+	f.SetConditions(runtimev1alpha1.Available())
+
+	serverUUID, err := e.resolveServer(ctx, f)
+	p := f.Spec.ForProvider
+	o := resource.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  err == nil && fip.Server.UUID == serverUUID && (p.ReversePointer == nil || *p.ReversePointer == fip.ReversePointer),
+		ConnectionDetails: floatingIPConnectionDetails(f),
+	}
+	return o, nil
+}
+
+// Create a new floating IP. Its address is stored as the external name.
+func (e *floatingIPExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	f, ok := mg.(*networkv1alpha1.FloatingIP)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotFloatingIP)
+	}
+	log.Info("Create", "floatingIP", f.Name)
+	f.SetConditions(runtimev1alpha1.Creating())
+
+	serverUUID, err := e.resolveServer(ctx, f)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	p := f.Spec.ForProvider
+	req := &network.FloatingIPRequest{
+		IPVersion:      p.IPVersion,
+		Server:         serverUUID,
+		ReversePointer: stringValue(p.ReversePointer),
+		Type:           p.Type,
+	}
+	req.Region = p.Region
+	if p.PrefixLength != nil {
+		req.PrefixLength = *p.PrefixLength
+	}
+	fip, err := e.networkClient.CreateFloatingIP(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create floating IP")
+	}
+
+	meta.SetExternalName(f, fip.IP())
+	observeFloatingIP(f, fip)
+	return resource.ExternalCreation{ConnectionDetails: floatingIPConnectionDetails(f)}, nil
+}
+
+// Update reassigns the floating IP to the desired server and updates its
+// reverse pointer.
+func (e *floatingIPExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	f, ok := mg.(*networkv1alpha1.FloatingIP)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotFloatingIP)
+	}
+	log.Info("Update", "floatingIP", f.Name)
+
+	serverUUID, err := e.resolveServer(ctx, f)
+	if err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	req := &network.FloatingIPUpdateRequest{
+		ReversePointer: stringValue(f.Spec.ForProvider.ReversePointer),
+	}
+	if serverUUID != "" {
+		req.Server = &serverUUID
+	}
+	err = e.networkClient.UpdateFloatingIP(ctx, floatingIPAddress(f), req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update floating IP")
+}
+
+// Delete the floating IP.
+func (e *floatingIPExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	f, ok := mg.(*networkv1alpha1.FloatingIP)
+	if !ok {
+		return errors.New(errNotFloatingIP)
+	}
+	log.Info("Delete", "floatingIP", f.Name)
+	f.SetConditions(runtimev1alpha1.Deleting())
+
+	address := floatingIPAddress(f)
+	if address == "" {
+		return nil
+	}
+	err := e.networkClient.DeleteFloatingIP(ctx, address)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete floating IP")
+	}
+	return nil
+}
+
+// resolveServer returns the UUID of the server the floating IP is assigned
+// to, which is either set explicitly or resolved from the referenced Server.
+func (e *floatingIPExternal) resolveServer(ctx context.Context, f *networkv1alpha1.FloatingIP) (string, error) {
+	ref := f.Spec.ForProvider.ServerRef
+	if ref == nil {
+		return f.Spec.ForProvider.Server, nil
+	}
+	s := &computev1alpha1.Server{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, "cannot get referenced Server")
+	}
+	if s.Status.AtProvider.UUID == "" {
+		return "", errors.Errorf("referenced Server %s is not ready", ref.Name)
+	}
+	return s.Status.AtProvider.UUID, nil
+}
+
+// floatingIPAddress returns the address of the floating IP, which is stored as
+// its external name. The external name defaults to the name of the
+// FloatingIP, which is only an address once the floating IP is created. The
+// observed address is used for FloatingIPs created before their address was
+// stored as their external name.
+func floatingIPAddress(f *networkv1alpha1.FloatingIP) string {
+	if name := meta.GetExternalName(f); name != f.GetName() && net.ParseIP(name) != nil {
+		return name
+	}
+	return f.Status.AtProvider.Address
+}
+
+// observeFloatingIP updates the status of the FloatingIP with the observed
+// floating IP.
+func observeFloatingIP(f *networkv1alpha1.FloatingIP, fip *cloudscale.FloatingIP) {
+	o := &f.Status.AtProvider
+	o.Address = fip.IP()
+	o.Network = fip.Network
+	o.ServerUUID = fip.Server.UUID
+	o.ReversePointer = fip.ReversePointer
+}
+
+// floatingIPConnectionDetails returns the address and network of the
+// floating IP.
+func floatingIPConnectionDetails(f *networkv1alpha1.FloatingIP) resource.ConnectionDetails {
+	return resource.ConnectionDetails{
+		runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(f.Status.AtProvider.Address),
+		resourceCredentialsSecretNetwork:                     []byte(f.Status.AtProvider.Network),
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
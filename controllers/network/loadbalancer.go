@@ -0,0 +1,271 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const (
+	errNotLoadBalancer = "managed resource is not a LoadBalancer"
+
+	defaultLoadBalancerFlavor = "lb-standard"
+
+	reasonLoadBalancerNotRunning runtimev1alpha1.ConditionReason = "Load balancer resource is not running"
+)
+
+// LoadBalancerController is responsible for adding the LoadBalancer
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type LoadBalancerController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile LoadBalancers using an ExternalClient produced by
+// loadBalancerConnecter, which satisfies the ExternalConnecter interface.
+func (r *LoadBalancerController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.LoadBalancerKindAPIVersion)).
+		For(&networkv1alpha1.LoadBalancer{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.LoadBalancerGroupVersionKind),
+			resource.WithExternalConnecter(&loadBalancerConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// loadBalancerConnecter satisfies the resource.ExternalConnecter interface.
+type loadBalancerConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a LoadBalancer) by
+// using the Provider it references to create a new network client.
+func (c *loadBalancerConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancer)
+	if !ok {
+		return nil, errors.New(errNotLoadBalancer)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, l.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancerExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type loadBalancerExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing load balancer, if any.
+func (e *loadBalancerExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancer)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotLoadBalancer)
+	}
+	log.Info("Observe", "loadBalancer", l.Name)
+
+	lb, err := e.networkClient.GetLoadBalancer(ctx, l.Status.AtProvider.UUID, meta.GetExternalName(l))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get load balancer")
+	}
+
+	observeLoadBalancer(l, lb)
+	l.SetConditions(loadBalancerCondition(lb.Status))
+
+	o := resource.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  lb.Name == meta.GetExternalName(l),
+		ConnectionDetails: loadBalancerConnectionDetails(l),
+	}
+	return o, nil
+}
+
+// Create a new load balancer.
+func (e *loadBalancerExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancer)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotLoadBalancer)
+	}
+	log.Info("Create", "loadBalancer", l.Name)
+	l.SetConditions(runtimev1alpha1.Creating())
+
+	p := l.Spec.ForProvider
+	req := &network.LoadBalancerRequest{
+		Name:   meta.GetExternalName(l),
+		Flavor: p.Flavor,
+	}
+	if req.Flavor == "" {
+		req.Flavor = defaultLoadBalancerFlavor
+	}
+	req.Zone = p.Zone
+	for _, vip := range p.VIPAddresses {
+		subnetUUID, err := resolveSubnet(ctx, e.kube, vip.SubnetRef, vip.Subnet)
+		if err != nil {
+			return resource.ExternalCreation{}, err
+		}
+		req.VIPAddresses = append(req.VIPAddresses, network.VIPAddressRequest{Subnet: subnetUUID, Address: vip.Address})
+	}
+	lb, err := e.networkClient.CreateLoadBalancer(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create load balancer")
+	}
+	observeLoadBalancer(l, lb)
+	return resource.ExternalCreation{ConnectionDetails: loadBalancerConnectionDetails(l)}, nil
+}
+
+// Update the name of the load balancer.
+func (e *loadBalancerExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancer)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotLoadBalancer)
+	}
+	log.Info("Update", "loadBalancer", l.Name)
+
+	req := &network.LoadBalancerRequest{
+		Name: meta.GetExternalName(l),
+	}
+	err := e.networkClient.UpdateLoadBalancer(ctx, l.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update load balancer")
+}
+
+// Delete the load balancer. Its pools, members, listeners and health monitors
+// are deleted with it.
+func (e *loadBalancerExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	l, ok := mg.(*networkv1alpha1.LoadBalancer)
+	if !ok {
+		return errors.New(errNotLoadBalancer)
+	}
+	log.Info("Delete", "loadBalancer", l.Name)
+	l.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteLoadBalancer(ctx, l.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete load balancer")
+	}
+	return nil
+}
+
+// observeLoadBalancer updates the status of the LoadBalancer with the
+// observed load balancer.
+func observeLoadBalancer(l *networkv1alpha1.LoadBalancer, lb *network.LoadBalancer) {
+	o := &l.Status.AtProvider
+	o.UUID = lb.UUID
+	o.Zone = lb.Zone.Slug
+	o.ProvisioningStatus = lb.Status
+	o.OperatingStatus = lb.OperatingStatus
+	o.VIPAddresses = nil
+	for _, vip := range lb.VIPAddresses {
+		o.VIPAddresses = append(o.VIPAddresses, vip.Address)
+	}
+}
+
+// loadBalancerConnectionDetails returns the first VIP address of the load
+// balancer as endpoint.
+func loadBalancerConnectionDetails(l *networkv1alpha1.LoadBalancer) resource.ConnectionDetails {
+	if len(l.Status.AtProvider.VIPAddresses) == 0 {
+		return nil
+	}
+	return resource.ConnectionDetails{
+		runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(l.Status.AtProvider.VIPAddresses[0]),
+	}
+}
+
+// loadBalancerCondition returns Available if the provisioning status of a load
+// balancer, pool, member, listener or health monitor is running, and a
+// condition that indicates it is being changed or has failed otherwise.
+// Resources which report no provisioning status are available once they
+// exist.
+func loadBalancerCondition(status string) runtimev1alpha1.Condition {
+	if status == network.LoadBalancerStatusRunning || status == "" {
+		return runtimev1alpha1.Available()
+	}
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonLoadBalancerNotRunning,
+		Message:            fmt.Sprintf("the provisioning status is %s", status),
+	}
+}
+
+// resolveLoadBalancer returns the UUID of the referenced LoadBalancer.
+func resolveLoadBalancer(ctx context.Context, kube client.Client, ref *corev1.LocalObjectReference) (string, error) {
+	if ref == nil {
+		return "", errors.New("no LoadBalancer referenced")
+	}
+	l := &networkv1alpha1.LoadBalancer{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, l); err != nil {
+		return "", errors.Wrap(err, "cannot get referenced LoadBalancer")
+	}
+	if l.Status.AtProvider.UUID == "" {
+		return "", errors.Errorf("referenced LoadBalancer %s is not ready", ref.Name)
+	}
+	return l.Status.AtProvider.UUID, nil
+}
+
+// resolvePool returns the UUID of the referenced LoadBalancerPool.
+func resolvePool(ctx context.Context, kube client.Client, ref *corev1.LocalObjectReference) (string, error) {
+	if ref == nil {
+		return "", errors.New("no LoadBalancerPool referenced")
+	}
+	p := &networkv1alpha1.LoadBalancerPool{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, p); err != nil {
+		return "", errors.Wrap(err, "cannot get referenced LoadBalancerPool")
+	}
+	if p.Status.AtProvider.UUID == "" {
+		return "", errors.Errorf("referenced LoadBalancerPool %s is not ready", ref.Name)
+	}
+	return p.Status.AtProvider.UUID, nil
+}
+
+// resolveSubnet returns the UUID of a subnet, which is either set explicitly
+// or resolved from the referenced Subnet.
+func resolveSubnet(ctx context.Context, kube client.Client, ref *corev1.LocalObjectReference, uuid string) (string, error) {
+	if ref == nil {
+		return uuid, nil
+	}
+	s := &networkv1alpha1.Subnet{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, "cannot get referenced Subnet")
+	}
+	if s.Status.AtProvider.UUID == "" {
+		return "", errors.Errorf("referenced Subnet %s is not ready", ref.Name)
+	}
+	return s.Status.AtProvider.UUID, nil
+}
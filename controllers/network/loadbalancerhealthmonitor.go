@@ -0,0 +1,225 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const errNotLoadBalancerHealthMonitor = "managed resource is not a LoadBalancerHealthMonitor"
+
+// LoadBalancerHealthMonitorController is responsible for adding the LoadBalancerHealthMonitor
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type LoadBalancerHealthMonitorController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile LoadBalancerHealthMonitors using an ExternalClient produced by
+// loadBalancerHealthMonitorConnecter, which satisfies the ExternalConnecter interface.
+func (r *LoadBalancerHealthMonitorController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.LoadBalancerHealthMonitorKindAPIVersion)).
+		For(&networkv1alpha1.LoadBalancerHealthMonitor{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.LoadBalancerHealthMonitorGroupVersionKind),
+			resource.WithExternalConnecter(&loadBalancerHealthMonitorConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// loadBalancerHealthMonitorConnecter satisfies the resource.ExternalConnecter interface.
+type loadBalancerHealthMonitorConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a LoadBalancerHealthMonitor) by
+// using the Provider it references to create a new network client.
+func (c *loadBalancerHealthMonitorConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	r, ok := mg.(*networkv1alpha1.LoadBalancerHealthMonitor)
+	if !ok {
+		return nil, errors.New(errNotLoadBalancerHealthMonitor)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, r.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancerHealthMonitorExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type loadBalancerHealthMonitorExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing health monitor, if any. Health monitors have no name,
+// a health monitor is looked up by its pool until it was created.
+func (e *loadBalancerHealthMonitorExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	h, ok := mg.(*networkv1alpha1.LoadBalancerHealthMonitor)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotLoadBalancerHealthMonitor)
+	}
+	log.Info("Observe", "loadBalancerHealthMonitor", h.Name)
+
+	poolUUID := h.Status.AtProvider.PoolUUID
+	if poolUUID == "" {
+		var err error
+		if poolUUID, err = resolvePool(ctx, e.kube, h.Spec.ForProvider.PoolRef); err != nil {
+			return resource.ExternalObservation{}, err
+		}
+	}
+	monitor, err := e.networkClient.GetLoadBalancerHealthMonitor(ctx, h.Status.AtProvider.UUID, poolUUID)
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get load balancer health monitor")
+	}
+
+	observeLoadBalancerHealthMonitor(h, monitor)
+	h.SetConditions(loadBalancerCondition(monitor.Status))
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: healthMonitorUpToDate(h.Spec.ForProvider, monitor),
+	}
+	return o, nil
+}
+
+// Create a new health monitor.
+func (e *loadBalancerHealthMonitorExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	h, ok := mg.(*networkv1alpha1.LoadBalancerHealthMonitor)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotLoadBalancerHealthMonitor)
+	}
+	log.Info("Create", "loadBalancerHealthMonitor", h.Name)
+	h.SetConditions(runtimev1alpha1.Creating())
+
+	poolUUID, err := resolvePool(ctx, e.kube, h.Spec.ForProvider.PoolRef)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	req := healthMonitorRequest(h.Spec.ForProvider)
+	req.Pool = poolUUID
+	req.Type = h.Spec.ForProvider.Type
+	monitor, err := e.networkClient.CreateLoadBalancerHealthMonitor(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create load balancer health monitor")
+	}
+	h.Status.AtProvider.UUID = monitor.UUID
+	h.Status.AtProvider.PoolUUID = poolUUID
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the timings, thresholds and HTTP options of the health monitor.
+func (e *loadBalancerHealthMonitorExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	h, ok := mg.(*networkv1alpha1.LoadBalancerHealthMonitor)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotLoadBalancerHealthMonitor)
+	}
+	log.Info("Update", "loadBalancerHealthMonitor", h.Name)
+
+	req := healthMonitorRequest(h.Spec.ForProvider)
+	err := e.networkClient.UpdateLoadBalancerHealthMonitor(ctx, h.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update load balancer health monitor")
+}
+
+// Delete the health monitor.
+func (e *loadBalancerHealthMonitorExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	h, ok := mg.(*networkv1alpha1.LoadBalancerHealthMonitor)
+	if !ok {
+		return errors.New(errNotLoadBalancerHealthMonitor)
+	}
+	log.Info("Delete", "loadBalancerHealthMonitor", h.Name)
+	h.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteLoadBalancerHealthMonitor(ctx, h.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete load balancer health monitor")
+	}
+	return nil
+}
+
+// observeLoadBalancerHealthMonitor updates the status of the
+// LoadBalancerHealthMonitor with the observed health monitor.
+func observeLoadBalancerHealthMonitor(h *networkv1alpha1.LoadBalancerHealthMonitor, monitor *network.LoadBalancerHealthMonitor) {
+	o := &h.Status.AtProvider
+	o.UUID = monitor.UUID
+	o.PoolUUID = monitor.Pool.UUID
+	o.ProvisioningStatus = monitor.Status
+	o.OperatingStatus = monitor.OperatingStatus
+}
+
+// healthMonitorRequest returns the request updating the timings, thresholds
+// and HTTP options of a health monitor. Omitted parameters keep their
+// default.
+func healthMonitorRequest(p networkv1alpha1.LoadBalancerHealthMonitorParameters) *network.LoadBalancerHealthMonitorRequest {
+	req := &network.LoadBalancerHealthMonitorRequest{
+		DelayS:        intValue(p.DelayS),
+		TimeoutS:      intValue(p.TimeoutS),
+		UpThreshold:   intValue(p.UpThreshold),
+		DownThreshold: intValue(p.DownThreshold),
+	}
+	if p.HTTP != nil {
+		req.HTTP = &network.LoadBalancerHealthMonitorHTTP{
+			ExpectedCodes: p.HTTP.ExpectedCodes,
+			Method:        p.HTTP.Method,
+			URLPath:       p.HTTP.URLPath,
+			Version:       p.HTTP.Version,
+			Host:          p.HTTP.Host,
+		}
+	}
+	return req
+}
+
+// healthMonitorUpToDate returns true if the timings, thresholds and HTTP
+// options of the health monitor match the desired parameters. Omitted
+// parameters aren't compared.
+func healthMonitorUpToDate(p networkv1alpha1.LoadBalancerHealthMonitorParameters, monitor *network.LoadBalancerHealthMonitor) bool {
+	if (p.DelayS != nil && *p.DelayS != monitor.DelayS) ||
+		(p.TimeoutS != nil && *p.TimeoutS != monitor.TimeoutS) ||
+		(p.UpThreshold != nil && *p.UpThreshold != monitor.UpThreshold) ||
+		(p.DownThreshold != nil && *p.DownThreshold != monitor.DownThreshold) {
+		return false
+	}
+	if p.HTTP == nil {
+		return true
+	}
+	if monitor.HTTP == nil {
+		return false
+	}
+	h, m := p.HTTP, monitor.HTTP
+	return (h.ExpectedCodes == nil || reflect.DeepEqual(h.ExpectedCodes, m.ExpectedCodes)) &&
+		(h.Method == "" || h.Method == m.Method) &&
+		(h.URLPath == "" || h.URLPath == m.URLPath) &&
+		(h.Version == "" || h.Version == m.Version) &&
+		(h.Host == nil || m.Host != nil && *h.Host == *m.Host)
+}
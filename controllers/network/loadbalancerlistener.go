@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const errNotLoadBalancerListener = "managed resource is not a LoadBalancerListener"
+
+// LoadBalancerListenerController is responsible for adding the LoadBalancerListener
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type LoadBalancerListenerController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile LoadBalancerListeners using an ExternalClient produced by
+// loadBalancerListenerConnecter, which satisfies the ExternalConnecter interface.
+func (r *LoadBalancerListenerController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.LoadBalancerListenerKindAPIVersion)).
+		For(&networkv1alpha1.LoadBalancerListener{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.LoadBalancerListenerGroupVersionKind),
+			resource.WithExternalConnecter(&loadBalancerListenerConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// loadBalancerListenerConnecter satisfies the resource.ExternalConnecter interface.
+type loadBalancerListenerConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a LoadBalancerListener) by
+// using the Provider it references to create a new network client.
+func (c *loadBalancerListenerConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	r, ok := mg.(*networkv1alpha1.LoadBalancerListener)
+	if !ok {
+		return nil, errors.New(errNotLoadBalancerListener)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, r.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancerListenerExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type loadBalancerListenerExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing listener, if any.
+func (e *loadBalancerListenerExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancerListener)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotLoadBalancerListener)
+	}
+	log.Info("Observe", "loadBalancerListener", l.Name)
+
+	poolUUID := l.Status.AtProvider.PoolUUID
+	if poolUUID == "" {
+		var err error
+		if poolUUID, err = resolvePool(ctx, e.kube, l.Spec.ForProvider.PoolRef); err != nil {
+			return resource.ExternalObservation{}, err
+		}
+	}
+	listener, err := e.networkClient.GetLoadBalancerListener(ctx, l.Status.AtProvider.UUID, poolUUID, meta.GetExternalName(l))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get load balancer listener")
+	}
+
+	observeLoadBalancerListener(l, listener)
+	l.SetConditions(loadBalancerCondition(listener.Status))
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: listenerUpToDate(meta.GetExternalName(l), l.Spec.ForProvider, listener),
+	}
+	return o, nil
+}
+
+// Create a new listener.
+func (e *loadBalancerListenerExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancerListener)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotLoadBalancerListener)
+	}
+	log.Info("Create", "loadBalancerListener", l.Name)
+	l.SetConditions(runtimev1alpha1.Creating())
+
+	poolUUID, err := resolvePool(ctx, e.kube, l.Spec.ForProvider.PoolRef)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	req := listenerRequest(meta.GetExternalName(l), l.Spec.ForProvider)
+	req.Pool = poolUUID
+	req.Protocol = l.Spec.ForProvider.Protocol
+	listener, err := e.networkClient.CreateLoadBalancerListener(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create load balancer listener")
+	}
+	l.Status.AtProvider.UUID = listener.UUID
+	l.Status.AtProvider.PoolUUID = poolUUID
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name, port, allowed CIDRs and timeouts of the listener.
+func (e *loadBalancerListenerExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	l, ok := mg.(*networkv1alpha1.LoadBalancerListener)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotLoadBalancerListener)
+	}
+	log.Info("Update", "loadBalancerListener", l.Name)
+
+	req := listenerRequest(meta.GetExternalName(l), l.Spec.ForProvider)
+	err := e.networkClient.UpdateLoadBalancerListener(ctx, l.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update load balancer listener")
+}
+
+// Delete the listener.
+func (e *loadBalancerListenerExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	l, ok := mg.(*networkv1alpha1.LoadBalancerListener)
+	if !ok {
+		return errors.New(errNotLoadBalancerListener)
+	}
+	log.Info("Delete", "loadBalancerListener", l.Name)
+	l.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteLoadBalancerListener(ctx, l.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete load balancer listener")
+	}
+	return nil
+}
+
+// observeLoadBalancerListener updates the status of the LoadBalancerListener
+// with the observed listener.
+func observeLoadBalancerListener(l *networkv1alpha1.LoadBalancerListener, listener *network.LoadBalancerListener) {
+	o := &l.Status.AtProvider
+	o.UUID = listener.UUID
+	o.PoolUUID = listener.Pool.UUID
+	o.LoadBalancerUUID = listener.LoadBalancer.UUID
+	o.ProvisioningStatus = listener.Status
+	o.OperatingStatus = listener.OperatingStatus
+}
+
+// listenerRequest returns the request updating the name, port, allowed CIDRs
+// and timeouts of a listener. Omitted timeouts keep their default.
+func listenerRequest(name string, p networkv1alpha1.LoadBalancerListenerParameters) *network.LoadBalancerListenerRequest {
+	allowedCIDRs := p.AllowedCIDRs
+	if allowedCIDRs == nil {
+		allowedCIDRs = []string{}
+	}
+	return &network.LoadBalancerListenerRequest{
+		Name:                   name,
+		ProtocolPort:           p.ProtocolPort,
+		AllowedCIDRs:           &allowedCIDRs,
+		TimeoutClientDataMS:    intValue(p.TimeoutClientDataMS),
+		TimeoutMemberConnectMS: intValue(p.TimeoutMemberConnectMS),
+		TimeoutMemberDataMS:    intValue(p.TimeoutMemberDataMS),
+	}
+}
+
+// listenerUpToDate returns true if the name, port, allowed CIDRs and
+// timeouts of the listener match the desired parameters. Omitted timeouts
+// aren't compared.
+func listenerUpToDate(name string, p networkv1alpha1.LoadBalancerListenerParameters, listener *network.LoadBalancerListener) bool {
+	if listener.Name != name || listener.ProtocolPort != p.ProtocolPort {
+		return false
+	}
+	if len(p.AllowedCIDRs) != len(listener.AllowedCIDRs) ||
+		(len(p.AllowedCIDRs) > 0 && !reflect.DeepEqual(p.AllowedCIDRs, listener.AllowedCIDRs)) {
+		return false
+	}
+	return (p.TimeoutClientDataMS == nil || *p.TimeoutClientDataMS == listener.TimeoutClientDataMS) &&
+		(p.TimeoutMemberConnectMS == nil || *p.TimeoutMemberConnectMS == listener.TimeoutMemberConnectMS) &&
+		(p.TimeoutMemberDataMS == nil || *p.TimeoutMemberDataMS == listener.TimeoutMemberDataMS)
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const errNotLoadBalancerPool = "managed resource is not a LoadBalancerPool"
+
+// LoadBalancerPoolController is responsible for adding the LoadBalancerPool
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type LoadBalancerPoolController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile LoadBalancerPools using an ExternalClient produced by
+// loadBalancerPoolConnecter, which satisfies the ExternalConnecter interface.
+func (r *LoadBalancerPoolController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.LoadBalancerPoolKindAPIVersion)).
+		For(&networkv1alpha1.LoadBalancerPool{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.LoadBalancerPoolGroupVersionKind),
+			resource.WithExternalConnecter(&loadBalancerPoolConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// loadBalancerPoolConnecter satisfies the resource.ExternalConnecter interface.
+type loadBalancerPoolConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a LoadBalancerPool) by
+// using the Provider it references to create a new network client.
+func (c *loadBalancerPoolConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	r, ok := mg.(*networkv1alpha1.LoadBalancerPool)
+	if !ok {
+		return nil, errors.New(errNotLoadBalancerPool)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, r.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancerPoolExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type loadBalancerPoolExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing pool, if any.
+func (e *loadBalancerPoolExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	p, ok := mg.(*networkv1alpha1.LoadBalancerPool)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotLoadBalancerPool)
+	}
+	log.Info("Observe", "loadBalancerPool", p.Name)
+
+	lbUUID := p.Status.AtProvider.LoadBalancerUUID
+	if lbUUID == "" {
+		var err error
+		if lbUUID, err = resolveLoadBalancer(ctx, e.kube, p.Spec.ForProvider.LoadBalancerRef); err != nil {
+			return resource.ExternalObservation{}, err
+		}
+	}
+	pool, err := e.networkClient.GetLoadBalancerPool(ctx, p.Status.AtProvider.UUID, lbUUID, meta.GetExternalName(p))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get load balancer pool")
+	}
+
+	observeLoadBalancerPool(p, pool)
+	p.SetConditions(loadBalancerCondition(pool.Status))
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: pool.Name == meta.GetExternalName(p),
+	}
+	return o, nil
+}
+
+// Create a new pool.
+func (e *loadBalancerPoolExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	p, ok := mg.(*networkv1alpha1.LoadBalancerPool)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotLoadBalancerPool)
+	}
+	log.Info("Create", "loadBalancerPool", p.Name)
+	p.SetConditions(runtimev1alpha1.Creating())
+
+	lbUUID, err := resolveLoadBalancer(ctx, e.kube, p.Spec.ForProvider.LoadBalancerRef)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	req := &network.LoadBalancerPoolRequest{
+		Name:         meta.GetExternalName(p),
+		LoadBalancer: lbUUID,
+		Algorithm:    p.Spec.ForProvider.Algorithm,
+		Protocol:     p.Spec.ForProvider.Protocol,
+	}
+	pool, err := e.networkClient.CreateLoadBalancerPool(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create load balancer pool")
+	}
+	p.Status.AtProvider.UUID = pool.UUID
+	p.Status.AtProvider.LoadBalancerUUID = pool.LoadBalancer.UUID
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name of the pool.
+func (e *loadBalancerPoolExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	p, ok := mg.(*networkv1alpha1.LoadBalancerPool)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotLoadBalancerPool)
+	}
+	log.Info("Update", "loadBalancerPool", p.Name)
+
+	req := &network.LoadBalancerPoolRequest{
+		Name: meta.GetExternalName(p),
+	}
+	err := e.networkClient.UpdateLoadBalancerPool(ctx, p.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update load balancer pool")
+}
+
+// Delete the pool. Its members and health monitor are deleted with it.
+func (e *loadBalancerPoolExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	p, ok := mg.(*networkv1alpha1.LoadBalancerPool)
+	if !ok {
+		return errors.New(errNotLoadBalancerPool)
+	}
+	log.Info("Delete", "loadBalancerPool", p.Name)
+	p.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteLoadBalancerPool(ctx, p.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete load balancer pool")
+	}
+	return nil
+}
+
+// observeLoadBalancerPool updates the status of the LoadBalancerPool with the
+// observed pool.
+func observeLoadBalancerPool(p *networkv1alpha1.LoadBalancerPool, pool *network.LoadBalancerPool) {
+	o := &p.Status.AtProvider
+	o.UUID = pool.UUID
+	o.LoadBalancerUUID = pool.LoadBalancer.UUID
+	o.ProvisioningStatus = pool.Status
+	o.OperatingStatus = pool.OperatingStatus
+}
@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const errNotLoadBalancerPoolMember = "managed resource is not a LoadBalancerPoolMember"
+
+// LoadBalancerPoolMemberController is responsible for adding the LoadBalancerPoolMember
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type LoadBalancerPoolMemberController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile LoadBalancerPoolMembers using an ExternalClient produced by
+// loadBalancerPoolMemberConnecter, which satisfies the ExternalConnecter interface.
+func (r *LoadBalancerPoolMemberController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.LoadBalancerPoolMemberKindAPIVersion)).
+		For(&networkv1alpha1.LoadBalancerPoolMember{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.LoadBalancerPoolMemberGroupVersionKind),
+			resource.WithExternalConnecter(&loadBalancerPoolMemberConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// loadBalancerPoolMemberConnecter satisfies the resource.ExternalConnecter interface.
+type loadBalancerPoolMemberConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a LoadBalancerPoolMember) by
+// using the Provider it references to create a new network client.
+func (c *loadBalancerPoolMemberConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	r, ok := mg.(*networkv1alpha1.LoadBalancerPoolMember)
+	if !ok {
+		return nil, errors.New(errNotLoadBalancerPoolMember)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, r.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancerPoolMemberExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type loadBalancerPoolMemberExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing pool member, if any.
+func (e *loadBalancerPoolMemberExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*networkv1alpha1.LoadBalancerPoolMember)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotLoadBalancerPoolMember)
+	}
+	log.Info("Observe", "loadBalancerPoolMember", m.Name)
+
+	poolUUID := m.Status.AtProvider.PoolUUID
+	if poolUUID == "" {
+		var err error
+		if poolUUID, err = resolvePool(ctx, e.kube, m.Spec.ForProvider.PoolRef); err != nil {
+			return resource.ExternalObservation{}, err
+		}
+	}
+	member, err := e.networkClient.GetLoadBalancerPoolMember(ctx, poolUUID, m.Status.AtProvider.UUID, meta.GetExternalName(m))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get load balancer pool member")
+	}
+
+	observeLoadBalancerPoolMember(m, member)
+	m.SetConditions(loadBalancerCondition(member.Status))
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: poolMemberUpToDate(meta.GetExternalName(m), m.Spec.ForProvider, member),
+	}
+	return o, nil
+}
+
+// Create a new pool member.
+func (e *loadBalancerPoolMemberExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*networkv1alpha1.LoadBalancerPoolMember)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotLoadBalancerPoolMember)
+	}
+	log.Info("Create", "loadBalancerPoolMember", m.Name)
+	m.SetConditions(runtimev1alpha1.Creating())
+
+	p := m.Spec.ForProvider
+	poolUUID, err := resolvePool(ctx, e.kube, p.PoolRef)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	subnetUUID, err := resolveSubnet(ctx, e.kube, p.SubnetRef, p.Subnet)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	req := poolMemberRequest(meta.GetExternalName(m), p)
+	req.Address = p.Address
+	req.Subnet = subnetUUID
+	member, err := e.networkClient.CreateLoadBalancerPoolMember(ctx, poolUUID, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create load balancer pool member")
+	}
+	m.Status.AtProvider.UUID = member.UUID
+	m.Status.AtProvider.PoolUUID = poolUUID
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name, ports and enabled state of the pool member.
+func (e *loadBalancerPoolMemberExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*networkv1alpha1.LoadBalancerPoolMember)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotLoadBalancerPoolMember)
+	}
+	log.Info("Update", "loadBalancerPoolMember", m.Name)
+
+	req := poolMemberRequest(meta.GetExternalName(m), m.Spec.ForProvider)
+	err := e.networkClient.UpdateLoadBalancerPoolMember(ctx, m.Status.AtProvider.PoolUUID, m.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update load balancer pool member")
+}
+
+// Delete the pool member.
+func (e *loadBalancerPoolMemberExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*networkv1alpha1.LoadBalancerPoolMember)
+	if !ok {
+		return errors.New(errNotLoadBalancerPoolMember)
+	}
+	log.Info("Delete", "loadBalancerPoolMember", m.Name)
+	m.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteLoadBalancerPoolMember(ctx, m.Status.AtProvider.PoolUUID, m.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete load balancer pool member")
+	}
+	return nil
+}
+
+// observeLoadBalancerPoolMember updates the status of the
+// LoadBalancerPoolMember with the observed member.
+func observeLoadBalancerPoolMember(m *networkv1alpha1.LoadBalancerPoolMember, member *network.LoadBalancerPoolMember) {
+	o := &m.Status.AtProvider
+	o.UUID = member.UUID
+	o.PoolUUID = member.Pool.UUID
+	o.ProvisioningStatus = member.Status
+	o.OperatingStatus = member.MonitorStatus
+}
+
+// poolMemberRequest returns the request updating the name, ports and enabled
+// state of a pool member. An omitted enabled state enables the member, so that
+// a member disabled by hand is enabled again.
+func poolMemberRequest(name string, p networkv1alpha1.LoadBalancerPoolMemberParameters) *network.LoadBalancerPoolMemberRequest {
+	enabled := p.Enabled == nil || *p.Enabled
+	req := &network.LoadBalancerPoolMemberRequest{
+		Name:         name,
+		Enabled:      &enabled,
+		ProtocolPort: p.ProtocolPort,
+	}
+	if p.MonitorPort != nil {
+		req.MonitorPort = *p.MonitorPort
+	}
+	return req
+}
+
+// poolMemberUpToDate returns true if the name, ports and enabled state of the
+// member match the desired parameters. An omitted monitor port isn't
+// compared.
+func poolMemberUpToDate(name string, p networkv1alpha1.LoadBalancerPoolMemberParameters, member *network.LoadBalancerPoolMember) bool {
+	enabled := p.Enabled == nil || *p.Enabled
+	return member.Name == name &&
+		member.Enabled == enabled &&
+		member.ProtocolPort == p.ProtocolPort &&
+		(p.MonitorPort == nil || *p.MonitorPort == member.MonitorPort)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const (
+	errNotNetwork = "managed resource is not a Network"
+
+	defaultMTU = 9000
+)
+
+var log = logging.Logger.WithName("network_controller")
+
+// NetworkController is responsible for adding the Network controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type NetworkController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile Networks using an ExternalClient produced by
+// networkConnecter, which satisfies the ExternalConnecter interface.
+func (r *NetworkController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.NetworkKindAPIVersion)).
+		For(&networkv1alpha1.Network{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.NetworkGroupVersionKind),
+			resource.WithExternalConnecter(&networkConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// networkConnecter satisfies the resource.ExternalConnecter interface.
+type networkConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a Network) by
+// using the Provider it references to create a new network client.
+func (c *networkConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	n, ok := mg.(*networkv1alpha1.Network)
+	if !ok {
+		return nil, errors.New(errNotNetwork)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, n.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &networkExternal{networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type networkExternal struct {
+	networkClient network.Service
+}
+
+// Observe the existing network, if any.
+func (e *networkExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	n, ok := mg.(*networkv1alpha1.Network)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotNetwork)
+	}
+	log.Info("Observe", "network", n.Name)
+
+	nw, err := e.networkClient.GetNetwork(ctx, n.Status.AtProvider.UUID, meta.GetExternalName(n))
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get network")
+	}
+
+	observeNetwork(n, nw)
+	n.SetConditions(runtimev1alpha1.Available())
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: nw.Name == meta.GetExternalName(n) && nw.MTU == mtu(n.Spec.ForProvider),
+	}
+	return o, nil
+}
+
+// Create a new network.
+func (e *networkExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	n, ok := mg.(*networkv1alpha1.Network)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotNetwork)
+	}
+	log.Info("Create", "network", n.Name)
+	n.SetConditions(runtimev1alpha1.Creating())
+
+	p := n.Spec.ForProvider
+	req := &network.NetworkRequest{
+		Name:                 meta.GetExternalName(n),
+		MTU:                  mtu(p),
+		AutoCreateIPv4Subnet: p.AutoCreateIPv4Subnet,
+	}
+	req.Zone = p.Zone
+	nw, err := e.networkClient.CreateNetwork(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create network")
+	}
+	observeNetwork(n, nw)
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the name and MTU of the network.
+func (e *networkExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	n, ok := mg.(*networkv1alpha1.Network)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotNetwork)
+	}
+	log.Info("Update", "network", n.Name)
+
+	req := &network.NetworkRequest{
+		Name: meta.GetExternalName(n),
+		MTU:  mtu(n.Spec.ForProvider),
+	}
+	err := e.networkClient.UpdateNetwork(ctx, n.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update network")
+}
+
+// Delete the network and its subnets.
+func (e *networkExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	n, ok := mg.(*networkv1alpha1.Network)
+	if !ok {
+		return errors.New(errNotNetwork)
+	}
+	log.Info("Delete", "network", n.Name)
+	n.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteNetwork(ctx, n.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete network")
+	}
+	return nil
+}
+
+// observeNetwork updates the status of the Network with the observed network.
+func observeNetwork(n *networkv1alpha1.Network, nw *network.Network) {
+	o := &n.Status.AtProvider
+	o.UUID = nw.UUID
+	o.MTU = nw.MTU
+	o.Zone = nw.Zone.Slug
+	o.Subnets = nil
+	for _, s := range nw.Subnets {
+		o.Subnets = append(o.Subnets, s.CIDR)
+	}
+}
+
+func mtu(p networkv1alpha1.NetworkParameters) int {
+	if p.MTU == nil {
+		return defaultMTU
+	}
+	return *p.MTU
+}
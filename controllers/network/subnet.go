@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	networkv1alpha1 "github.com/vshn/stack-cloudscale/api/network/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/network"
+)
+
+const errNotSubnet = "managed resource is not a Subnet"
+
+// SubnetController is responsible for adding the Subnet controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type SubnetController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile Subnets using an ExternalClient produced by
+// subnetConnecter, which satisfies the ExternalConnecter interface.
+func (r *SubnetController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(networkv1alpha1.SubnetKindAPIVersion)).
+		For(&networkv1alpha1.Subnet{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(networkv1alpha1.SubnetGroupVersionKind),
+			resource.WithExternalConnecter(&subnetConnecter{client: mgr.GetClient(), newNetworkClient: network.NewClient})))
+}
+
+// subnetConnecter satisfies the resource.ExternalConnecter interface.
+type subnetConnecter struct {
+	client           client.Client
+	newNetworkClient func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) network.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be a Subnet) by using
+// the Provider it references to create a new network client.
+func (c *subnetConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*networkv1alpha1.Subnet)
+	if !ok {
+		return nil, errors.New(errNotSubnet)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, s.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &subnetExternal{kube: c.client, networkClient: c.newNetworkClient(ctx, cfg, nil)}, nil
+}
+
+type subnetExternal struct {
+	kube          client.Client
+	networkClient network.Service
+}
+
+// Observe the existing subnet, if any. The referenced Network is only resolved
+// on creation, so that the subnet can still be observed and deleted once the
+// Network is gone.
+func (e *subnetExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*networkv1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSubnet)
+	}
+	log.Info("Observe", "subnet", s.Name)
+
+	networkUUID := s.Status.AtProvider.NetworkUUID
+	if networkUUID == "" {
+		networkUUID = s.Spec.ForProvider.Network
+	}
+	if s.Status.AtProvider.UUID == "" && networkUUID == "" {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	subnet, err := e.networkClient.GetSubnet(ctx, s.Status.AtProvider.UUID, networkUUID, s.Spec.ForProvider.CIDR)
+	if clients.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get subnet")
+	}
+
+	observeSubnet(s, subnet)
+	s.SetConditions(runtimev1alpha1.Available())
+
+	o := resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: subnetUpToDate(s.Spec.ForProvider, subnet),
+	}
+	return o, nil
+}
+
+// Create a new subnet.
+func (e *subnetExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*networkv1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSubnet)
+	}
+	log.Info("Create", "subnet", s.Name)
+	s.SetConditions(runtimev1alpha1.Creating())
+
+	if err := e.resolveNetwork(ctx, s); err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	p := s.Spec.ForProvider
+	req := &network.SubnetRequest{
+		CIDR:           p.CIDR,
+		Network:        s.Status.AtProvider.NetworkUUID,
+		GatewayAddress: p.GatewayAddress,
+	}
+	if p.DNSServers != nil {
+		req.DNSServers = &p.DNSServers
+	}
+	subnet, err := e.networkClient.CreateSubnet(ctx, req)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create subnet")
+	}
+	observeSubnet(s, subnet)
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the gateway address and DNS servers of the subnet.
+func (e *subnetExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	s, ok := mg.(*networkv1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotSubnet)
+	}
+	log.Info("Update", "subnet", s.Name)
+
+	p := s.Spec.ForProvider
+	req := &network.SubnetRequest{
+		GatewayAddress: p.GatewayAddress,
+	}
+	if p.DNSServers != nil {
+		req.DNSServers = &p.DNSServers
+	}
+	err := e.networkClient.UpdateSubnet(ctx, s.Status.AtProvider.UUID, req)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update subnet")
+}
+
+// Delete the subnet.
+func (e *subnetExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*networkv1alpha1.Subnet)
+	if !ok {
+		return errors.New(errNotSubnet)
+	}
+	log.Info("Delete", "subnet", s.Name)
+	s.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networkClient.DeleteSubnet(ctx, s.Status.AtProvider.UUID)
+	if err != nil && !clients.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete subnet")
+	}
+	return nil
+}
+
+// resolveNetwork sets the UUID of the network of the subnet, which is either
+// set explicitly or resolved from the referenced Network.
+func (e *subnetExternal) resolveNetwork(ctx context.Context, s *networkv1alpha1.Subnet) error {
+	ref := s.Spec.ForProvider.NetworkRef
+	if ref == nil {
+		s.Status.AtProvider.NetworkUUID = s.Spec.ForProvider.Network
+		return nil
+	}
+	n := &networkv1alpha1.Network{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, n); err != nil {
+		return errors.Wrap(err, "cannot get referenced Network")
+	}
+	if n.Status.AtProvider.UUID == "" {
+		return errors.Errorf("referenced Network %s is not ready", ref.Name)
+	}
+	s.Status.AtProvider.NetworkUUID = n.Status.AtProvider.UUID
+	return nil
+}
+
+// observeSubnet updates the status of the Subnet with the observed subnet.
+func observeSubnet(s *networkv1alpha1.Subnet, subnet *network.Subnet) {
+	o := &s.Status.AtProvider
+	o.UUID = subnet.UUID
+	o.NetworkUUID = subnet.Network.UUID
+	o.GatewayAddress = subnet.GatewayAddress
+	o.DNSServers = subnet.DNSServers
+}
+
+// subnetUpToDate returns true if the gateway address and DNS servers of the
+// subnet match the desired parameters. Omitted parameters are not compared.
+func subnetUpToDate(p networkv1alpha1.SubnetParameters, subnet *network.Subnet) bool {
+	if p.GatewayAddress != nil && *p.GatewayAddress != subnet.GatewayAddress {
+		return false
+	}
+	return p.DNSServers == nil || reflect.DeepEqual(p.DNSServers, subnet.DNSServers)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provider contains the controller verifying the credentials of the
+// Cloudscale Providers.
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	cloudscalev1alpha1 "github.com/vshn/stack-cloudscale/api/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+)
+
+const (
+	// verifyInterval is the time between two verifications of the token of a
+	// Provider.
+	verifyInterval = 5 * time.Minute
+
+	verifyTimeout = 1 * time.Minute
+)
+
+var log = logging.Logger.WithName("provider_controller")
+
+// Controller is responsible for adding the Provider controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type Controller struct{}
+
+// SetupWithManager instantiates a new controller using a Reconciler, which
+// periodically verifies the token of each Provider. Changes of the spec of a
+// Provider and of its credentials Secret trigger a verification, the status
+// updates of the Reconciler don't.
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	r := &Reconciler{
+		kube:        mgr.GetClient(),
+		verifyToken: clients.VerifyToken,
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(cloudscalev1alpha1.ProviderKindAPIVersion)).
+		For(&cloudscalev1alpha1.Provider{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.providersOfSecret),
+		}).
+		WithEventFilter(specOrSecretChanged).
+		Complete(r)
+}
+
+// specOrSecretChanged passes the updates of Providers which changed their spec
+// and all updates of Secrets, which have no generation.
+var specOrSecretChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if _, ok := e.ObjectNew.(*corev1.Secret); ok {
+			return true
+		}
+		return predicate.GenerationChangedPredicate{}.Update(e)
+	},
+}
+
+// Reconciler verifies the token of a Provider and records the result in the
+// status of the Provider.
+type Reconciler struct {
+	kube        client.Client
+	verifyToken func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) (cloudscalev1alpha1.TokenScope, error)
+}
+
+// Reconcile verifies the token of the Provider. The Provider is Available if
+// the Cloudscale API accepts the token and Unavailable otherwise. It is
+// requeued to be verified again after the verify interval.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	log.V(1).Info("Reconciling", "request", req)
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	p := &cloudscalev1alpha1.Provider{}
+	if err := r.kube.Get(ctx, req.NamespacedName, p); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrap(err, "cannot get Provider")
+	}
+
+	before := p.Status.DeepCopy()
+	scope, err := r.verify(ctx, p)
+	if err != nil {
+		log.Info("Token verification failed", "provider", p.Name, "error", err.Error())
+		p.Status.SetConditions(runtimev1alpha1.Unavailable().WithMessage(err.Error()))
+	} else {
+		now := metav1.Now()
+		p.Status.SetConditions(runtimev1alpha1.Available())
+		p.Status.LastVerified = &now
+		p.Status.TokenScope = scope
+	}
+
+	if statusChanged(before, &p.Status) {
+		if err := r.kube.Status().Update(ctx, p); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "cannot update Provider status")
+		}
+	}
+	return reconcile.Result{RequeueAfter: verifyInterval}, nil
+}
+
+// providersOfSecret returns a request for each Provider whose credentials are
+// stored in the Secret.
+func (r *Reconciler) providersOfSecret(o handler.MapObject) []reconcile.Request {
+	providers := &cloudscalev1alpha1.ProviderList{}
+	if err := r.kube.List(context.Background(), providers); err != nil {
+		log.Info("Cannot list Providers", "secret", o.Meta.GetName(), "error", err.Error())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range providers.Items {
+		ref := p.Spec.Secret
+		if ref.Name == o.Meta.GetName() && ref.Namespace == o.Meta.GetNamespace() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.Name}})
+		}
+	}
+	return requests
+}
+
+// statusChanged returns true if the status differs from the previous status,
+// ignoring the last transition times of the conditions.
+func statusChanged(before, after *cloudscalev1alpha1.ProviderStatus) bool {
+	return !before.ConditionedStatus.Equal(&after.ConditionedStatus) ||
+		!before.LastVerified.Equal(after.LastVerified) ||
+		before.TokenScope != after.TokenScope
+}
+
+// verify returns the scope of the token of the Provider, or an error if the
+// configuration of the Provider is invalid or the token is rejected.
+func (r *Reconciler) verify(ctx context.Context, p *cloudscalev1alpha1.Provider) (cloudscalev1alpha1.TokenScope, error) {
+	cfg, err := clients.GetConfig(ctx, r.kube, p)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Token == "" {
+		return "", errors.Errorf("Provider secret has no key %s", p.Spec.Secret.Key)
+	}
+	return r.verifyToken(ctx, cfg, nil)
+}
@@ -149,7 +149,8 @@ func ConfigureS3Bucket(_ context.Context, cm resource.Claim, cs resource.Class,
 		ResourceSpec: runtimev1alpha1.ResourceSpec{
 			ReclaimPolicy: runtimev1alpha1.ReclaimRetain,
 		},
-		ForProvider: s3BucketClass.SpecTemplate.ForProvider,
+		ForProvider:             s3BucketClass.SpecTemplate.ForProvider,
+		ConnectionSecretFormats: s3BucketClass.SpecTemplate.ConnectionSecretFormats,
 	}
 
 	if s3BucketClass.SpecTemplate.ReclaimPolicy != "" {
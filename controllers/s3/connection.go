@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"fmt"
+	"net/url"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients/s3"
+)
+
+const (
+	resourceCredentialsSecretBucketname      = "bucketname"
+	resourceCredentialsSecretWebsiteEndpoint = "websiteEndpoint"
+	resourceCredentialsSecretRegion          = "region"
+	resourceCredentialsSecretHost            = "host"
+
+	resourceCredentialsSecretAWSCredentials = "credentials"
+	resourceCredentialsSecretRclone         = "rclone.conf"
+	resourceCredentialsSecretS3cmd          = ".s3cfg"
+)
+
+const awsCredentialsFormat = `[default]
+aws_access_key_id = %s
+aws_secret_access_key = %s
+`
+
+const rcloneFormat = `[cloudscale]
+type = s3
+provider = Ceph
+access_key_id = %s
+secret_access_key = %s
+endpoint = %s
+region = %s
+`
+
+const s3cmdFormat = `[default]
+access_key = %s
+secret_key = %s
+host_base = %s
+host_bucket = %s
+bucket_location = %s
+use_https = %s
+`
+
+// connectionDetails returns the details required to connect to the bucket
+// at the S3 endpoint of the client with the supplied credentials, in all
+// formats requested by the bucket.
+func connectionDetails(bucket *storagev1alpha1.S3Bucket, s3Client s3.Service, accessKey, secretKey string) resource.ConnectionDetails {
+	bucketName := meta.GetExternalName(bucket)
+	region := bucket.Spec.ForProvider.Region
+	endpoint := s3Client.Endpoint(region)
+
+	// Endpoints without a scheme are used as host and served over HTTPS.
+	host, useHTTPS := endpoint, "True"
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+		if u.Scheme == "http" {
+			useHTTPS = "False"
+		}
+	}
+
+	cd := resource.ConnectionDetails{
+		runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(accessKey),
+		runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(secretKey),
+		runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(endpoint),
+		resourceCredentialsSecretBucketname:                  []byte(bucketName),
+		resourceCredentialsSecretRegion:                      []byte(region),
+		resourceCredentialsSecretHost:                        []byte(host),
+	}
+	if bucket.Spec.ForProvider.Website != nil {
+		cd[resourceCredentialsSecretWebsiteEndpoint] = []byte(s3Client.WebsiteEndpoint(bucketName, region))
+	}
+
+	for _, f := range bucket.Spec.ConnectionSecretFormats {
+		switch f {
+		case storagev1alpha1.ConnectionSecretFormatAWSCredentials:
+			cd[resourceCredentialsSecretAWSCredentials] = []byte(fmt.Sprintf(awsCredentialsFormat, accessKey, secretKey))
+		case storagev1alpha1.ConnectionSecretFormatRclone:
+			cd[resourceCredentialsSecretRclone] = []byte(fmt.Sprintf(rcloneFormat, accessKey, secretKey, endpoint, region))
+		case storagev1alpha1.ConnectionSecretFormatS3cmd:
+			cd[resourceCredentialsSecretS3cmd] = []byte(fmt.Sprintf(s3cmdFormat, accessKey, secretKey, host, host, region, useHTTPS))
+		case storagev1alpha1.ConnectionSecretFormatEnv:
+			cd["AWS_ACCESS_KEY_ID"] = []byte(accessKey)
+			cd["AWS_SECRET_ACCESS_KEY"] = []byte(secretKey)
+			cd["AWS_DEFAULT_REGION"] = []byte(region)
+			cd["AWS_ENDPOINT_URL"] = []byte(endpoint)
+		}
+	}
+	return cd
+}
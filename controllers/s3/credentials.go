@@ -0,0 +1,226 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"fmt"
+
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients/s3"
+)
+
+const errSecretConflict = "cannot establish control of existing connection secret"
+
+// Tags of the objects users backing access credentials, which prove that the
+// objects user is owned by the S3Bucket.
+const (
+	tagBucketUID         = "cloudscale.crossplane.io/bucket-uid"
+	tagAccessCredentials = "cloudscale.crossplane.io/access-credentials"
+)
+
+// observeAccessCredentials publishes the access credentials of the bucket and
+// returns true if their objects users are up to date.
+func (e *external) observeAccessCredentials(ctx context.Context, bucket *storagev1alpha1.S3Bucket) (bool, error) {
+	p := bucket.Spec.ForProvider
+	upToDate := len(p.AccessCredentials) == len(bucket.Status.AtProvider.AccessCredentials)
+
+	for _, c := range p.AccessCredentials {
+		userID := accessCredentialsUserID(bucket, c.Name)
+		if userID == "" {
+			upToDate = false
+			continue
+		}
+		user, err := e.s3Client.GetObjectsUser(ctx, userID, "")
+		if s3.IsErrorNotFound(err) {
+			upToDate = false
+			continue
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "cannot get objects user of access credentials %s", c.Name)
+		}
+
+		if user.DisplayName != accessCredentialsUserName(bucket, c) || !tagsEqual(accessCredentialsUserTags(bucket, c), user.Tags) {
+			upToDate = false
+		}
+		if err := e.publishAccessCredentials(ctx, bucket, c, user); err != nil {
+			return false, err
+		}
+	}
+	return upToDate, nil
+}
+
+// reconcileAccessCredentials creates or updates the objects users of the
+// access credentials, publishes their keys and deletes the objects users of
+// removed access credentials.
+func (e *external) reconcileAccessCredentials(ctx context.Context, bucket *storagev1alpha1.S3Bucket) error {
+	p := bucket.Spec.ForProvider
+	observed := make([]storagev1alpha1.AccessCredentialsObservation, 0, len(p.AccessCredentials))
+	wanted := map[string]bool{}
+
+	for _, c := range p.AccessCredentials {
+		user, err := e.createOrUpdateAccessCredentialsUser(ctx, bucket, c)
+		if err != nil {
+			return errors.Wrapf(err, "cannot create objects user of access credentials %s", c.Name)
+		}
+		observed = append(observed, storagev1alpha1.AccessCredentialsObservation{Name: c.Name, ObjectUserID: user.ID})
+		wanted[c.Name] = true
+
+		if err := e.publishAccessCredentials(ctx, bucket, c, user); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range bucket.Status.AtProvider.AccessCredentials {
+		if wanted[o.Name] {
+			continue
+		}
+		err := e.s3Client.DeleteObjectsUser(ctx, o.ObjectUserID)
+		if err != nil && !s3.IsErrorNotFound(err) {
+			return errors.Wrapf(err, "cannot delete objects user of access credentials %s", o.Name)
+		}
+	}
+
+	bucket.Status.AtProvider.AccessCredentials = observed
+	return nil
+}
+
+// createOrUpdateAccessCredentialsUser creates or updates the objects user
+// backing the access credentials. Without an ID in the status, only an objects
+// user tagged as owned by the bucket is updated, e.g. if the status was lost
+// after its creation. Objects users are never looked up by their display name.
+func (e *external) createOrUpdateAccessCredentialsUser(ctx context.Context, bucket *storagev1alpha1.S3Bucket, c storagev1alpha1.AccessCredentials) (*cloudscale.ObjectsUser, error) {
+	name := accessCredentialsUserName(bucket, c)
+	tags := accessCredentialsUserTags(bucket, c)
+
+	userID := accessCredentialsUserID(bucket, c.Name)
+	if userID == "" {
+		user, err := e.s3Client.FindObjectsUser(ctx, map[string]string{
+			tagBucketUID:         tags[tagBucketUID],
+			tagAccessCredentials: tags[tagAccessCredentials],
+		})
+		switch {
+		case s3.IsErrorNotFound(err):
+			return e.s3Client.CreateObjectsUser(ctx, name, tags)
+		case err != nil:
+			return nil, err
+		}
+		userID = user.ID
+	}
+	return e.s3Client.CreateOrUpdateObjectsUser(ctx, userID, name, &tags)
+}
+
+// deleteAccessCredentials deletes the objects users of all access
+// credentials. Their connection secrets are garbage collected with the
+// S3Bucket.
+func (e *external) deleteAccessCredentials(ctx context.Context, bucket *storagev1alpha1.S3Bucket) error {
+	for _, o := range bucket.Status.AtProvider.AccessCredentials {
+		err := e.s3Client.DeleteObjectsUser(ctx, o.ObjectUserID)
+		if err != nil && !s3.IsErrorNotFound(err) {
+			return errors.Wrapf(err, "cannot delete objects user of access credentials %s", o.Name)
+		}
+	}
+	return nil
+}
+
+// publishAccessCredentials writes the keys of the objects user to the
+// connection secret of the access credentials. The secret is controlled by
+// the S3Bucket.
+func (e *external) publishAccessCredentials(ctx context.Context, bucket *storagev1alpha1.S3Bucket, c storagev1alpha1.AccessCredentials, user *cloudscale.ObjectsUser) error {
+	accessKey, secretKey, err := s3.GetKeys(user)
+	if err != nil {
+		return err
+	}
+
+	ref := c.WriteConnectionSecretToReference
+	s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, e.kube, s, func() error {
+		owner := metav1.GetControllerOf(s)
+		if owner == nil {
+			s.SetOwnerReferences(append(s.GetOwnerReferences(),
+				meta.AsController(meta.ReferenceTo(bucket, storagev1alpha1.S3BucketGroupVersionKind))))
+		} else if owner.UID != bucket.GetUID() {
+			return errors.New(errSecretConflict)
+		}
+
+		if s.Data == nil {
+			s.Data = map[string][]byte{}
+		}
+		for k, v := range connectionDetails(bucket, e.s3Client, accessKey, secretKey) {
+			s.Data[k] = v
+		}
+		return nil
+	})
+	return errors.Wrapf(err, "cannot publish access credentials %s", c.Name)
+}
+
+// accessPolicy returns the policy of the bucket, including the statements
+// granting access to the objects users of its access credentials.
+func accessPolicy(bucket *storagev1alpha1.S3Bucket) (string, error) {
+	var grants []s3.AccessGrant
+	for _, c := range bucket.Spec.ForProvider.AccessCredentials {
+		if userID := accessCredentialsUserID(bucket, c.Name); userID != "" {
+			grants = append(grants, s3.AccessGrant{UserID: userID, Access: c.Access})
+		}
+	}
+	return s3.AccessPolicy(meta.GetExternalName(bucket), stringValue(bucket.Spec.ForProvider.Policy), grants)
+}
+
+// hasPolicy returns true if the bucket policy is set explicitly or has to
+// grant access to access credentials.
+func hasPolicy(bucket *storagev1alpha1.S3Bucket) bool {
+	return bucket.Spec.ForProvider.Policy != nil || len(bucket.Spec.ForProvider.AccessCredentials) > 0
+}
+
+// accessCredentialsUserID returns the ID of the objects user backing the
+// named access credentials, or an empty string if it wasn't created yet.
+func accessCredentialsUserID(bucket *storagev1alpha1.S3Bucket, name string) string {
+	for _, o := range bucket.Status.AtProvider.AccessCredentials {
+		if o.Name == name {
+			return o.ObjectUserID
+		}
+	}
+	return ""
+}
+
+// accessCredentialsUserName returns the display name of the objects user
+// backing the access credentials.
+func accessCredentialsUserName(bucket *storagev1alpha1.S3Bucket, c storagev1alpha1.AccessCredentials) string {
+	return fmt.Sprintf("%s-%s", meta.GetExternalName(bucket), c.Name)
+}
+
+// accessCredentialsUserTags returns the tags of the objects user backing the
+// access credentials: the tags of the bucket and the ones proving that it is
+// owned by the bucket.
+func accessCredentialsUserTags(bucket *storagev1alpha1.S3Bucket, c storagev1alpha1.AccessCredentials) map[string]string {
+	tags := map[string]string{}
+	if bucket.Spec.ForProvider.Tags != nil {
+		for k, v := range *bucket.Spec.ForProvider.Tags {
+			tags[k] = v
+		}
+	}
+	tags[tagBucketUID] = string(bucket.GetUID())
+	tags[tagAccessCredentials] = c.Name
+	return tags
+}
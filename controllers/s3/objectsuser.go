@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2019, VSHN AG, info@vshn.ch
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
+	"github.com/vshn/stack-cloudscale/clients"
+	"github.com/vshn/stack-cloudscale/clients/s3"
+)
+
+const errNotObjectsUser = "managed resource is not an ObjectsUser"
+
+// ObjectsUserController is responsible for adding the ObjectsUser
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type ObjectsUserController struct{}
+
+// SetupWithManager instantiates a new controller using a resource.ManagedReconciler
+// configured to reconcile ObjectsUsers using an ExternalClient produced by
+// objectsUserConnecter, which satisfies the ExternalConnecter interface.
+func (r *ObjectsUserController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(storagev1alpha1.ObjectsUserKindAPIVersion)).
+		For(&storagev1alpha1.ObjectsUser{}).
+		Owns(&corev1.Secret{}).
+		Complete(resource.NewManagedReconciler(mgr,
+			resource.ManagedKind(storagev1alpha1.ObjectsUserGroupVersionKind),
+			resource.WithExternalConnecter(&objectsUserConnecter{client: mgr.GetClient(), newS3Client: s3.NewClient})))
+}
+
+// objectsUserConnecter satisfies the resource.ExternalConnecter interface.
+type objectsUserConnecter struct {
+	client      client.Client
+	newS3Client func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) s3.Service
+}
+
+// Connect to the supplied resource.Managed (presumed to be an ObjectsUser) by
+// using the Provider it references to create a new S3 client.
+func (c *objectsUserConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	u, ok := mg.(*storagev1alpha1.ObjectsUser)
+	if !ok {
+		return nil, errors.New(errNotObjectsUser)
+	}
+
+	cfg, err := clients.GetProviderConfig(ctx, c.client, u.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	return &objectsUserExternal{s3Client: c.newS3Client(ctx, cfg, nil)}, nil
+}
+
+type objectsUserExternal struct {
+	s3Client s3.Service
+}
+
+// Observe the existing objects user, if any.
+func (e *objectsUserExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	u, ok := mg.(*storagev1alpha1.ObjectsUser)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotObjectsUser)
+	}
+	log.Info("Observe", "objectsUser", u.Name)
+
+	user, err := e.s3Client.GetObjectsUser(ctx, u.Status.AtProvider.ID, meta.GetExternalName(u))
+	if s3.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot get objects user")
+	}
+
+	accessKey, secretKey, err := s3.GetKeys(user)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+	u.Status.AtProvider.ID = user.ID
+	u.SetConditions(runtimev1alpha1.Available())
+
+	tags := map[string]string{}
+	if u.Spec.ForProvider.Tags != nil {
+		tags = *u.Spec.ForProvider.Tags
+	}
+	o := resource.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  user.DisplayName == meta.GetExternalName(u) && tagsEqual(tags, user.Tags),
+		ConnectionDetails: objectsUserConnectionDetails(accessKey, secretKey),
+	}
+	return o, nil
+}
+
+// Create a new objects user.
+func (e *objectsUserExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	u, ok := mg.(*storagev1alpha1.ObjectsUser)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotObjectsUser)
+	}
+	log.Info("Create", "objectsUser", u.Name)
+	u.SetConditions(runtimev1alpha1.Creating())
+
+	user, err := e.s3Client.CreateOrUpdateObjectsUser(ctx, u.Status.AtProvider.ID, meta.GetExternalName(u), u.Spec.ForProvider.Tags)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create objects user")
+	}
+	u.Status.AtProvider.ID = user.ID
+
+	accessKey, secretKey, err := s3.GetKeys(user)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	return resource.ExternalCreation{ConnectionDetails: objectsUserConnectionDetails(accessKey, secretKey)}, nil
+}
+
+// Update the display name and tags of the objects user.
+func (e *objectsUserExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	u, ok := mg.(*storagev1alpha1.ObjectsUser)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotObjectsUser)
+	}
+	log.Info("Update", "objectsUser", u.Name)
+
+	_, err := e.s3Client.CreateOrUpdateObjectsUser(ctx, u.Status.AtProvider.ID, meta.GetExternalName(u), u.Spec.ForProvider.Tags)
+	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update objects user")
+}
+
+// Delete the objects user. Buckets owned by the user are not deleted.
+func (e *objectsUserExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	u, ok := mg.(*storagev1alpha1.ObjectsUser)
+	if !ok {
+		return errors.New(errNotObjectsUser)
+	}
+	log.Info("Delete", "objectsUser", u.Name)
+	u.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.s3Client.DeleteObjectsUser(ctx, u.Status.AtProvider.ID)
+	if err != nil && !s3.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete objects user")
+	}
+	return nil
+}
+
+func objectsUserConnectionDetails(accessKey, secretKey string) resource.ConnectionDetails {
+	return resource.ConnectionDetails{
+		runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(accessKey),
+		runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(secretKey),
+	}
+}
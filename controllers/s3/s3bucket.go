@@ -20,33 +20,57 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	cloudscale "github.com/cloudscale-ch/cloudscale-go-sdk"
 	"github.com/pkg/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	storagev1alpha1 "github.com/vshn/stack-cloudscale/api/storage/v1alpha1"
-	cloudscalev1alpha1 "github.com/vshn/stack-cloudscale/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/vshn/stack-cloudscale/clients"
 	"github.com/vshn/stack-cloudscale/clients/s3"
 )
 
 const (
-	errNotInstance = "managed resource is not an S3Bucket"
+	errNotInstance       = "managed resource is not an S3Bucket"
+	errDeletionProtected = "bucket is protected from deletion"
+	errAdoptNotFound     = "bucket to adopt does not exist"
 
 	statusOnline   = "Online"
 	statusCreating = "Creating"
 	statusDeleting = "Deleting"
 
-	resourceCredentialsSecretBucketname = "bucketname"
+	// usageMeasurementInterval is the minimum time between two measurements
+	// of the bucket usage.
+	usageMeasurementInterval = 1 * time.Hour
+	defaultUsageSampleLimit  = 10000
+
+	// defaultKeyRotationGracePeriod is the time a retired key stays valid
+	// after a rotation.
+	defaultKeyRotationGracePeriod = 24 * time.Hour
+)
+
+// reasonDeletionProtected is the reason of a resource which is not deleted
+// because of its deletion protection.
+const reasonDeletionProtected runtimev1alpha1.ConditionReason = "Managed resource is protected from deletion"
+
+// Reasons of a bucket which cannot be adopted.
+const (
+	reasonAdoptNotOwned runtimev1alpha1.ConditionReason = "Bucket to adopt is owned by another objects user"
+	reasonAdoptNotFound runtimev1alpha1.ConditionReason = "Bucket to adopt does not exist"
 )
 
 var log = logging.Logger.WithName("s3bucket_controller")
@@ -71,7 +95,7 @@ func (r *BucketController) SetupWithManager(mgr ctrl.Manager) error {
 // Connecter satisfies the resource.ExternalConnecter interface.
 type connecter struct {
 	client      client.Client
-	newS3Client func(ctx context.Context, cloudscaleToken string, httpClient *http.Client) s3.Service
+	newS3Client func(ctx context.Context, config *clients.ProviderConfig, httpClient *http.Client) s3.Service
 }
 
 // Connect to the supplied resource.Managed (presumed to be a
@@ -87,29 +111,23 @@ func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (resource.
 		return nil, errors.New(errNotInstance)
 	}
 
-	// Get the Provider referenced by the S3Bucket.
-	p := &cloudscalev1alpha1.Provider{}
-	if err := c.client.Get(ctx, meta.NamespacedNameOf(i.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, "cannot get Provider")
-	}
-
-	// Get the Secret referenced by the Provider.
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.Secret.Namespace, Name: p.Spec.Secret.Name}
-	if err := c.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get Provider secret %s", n)
+	cfg, err := clients.GetProviderConfig(ctx, c.client, i.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create and return a new S3 client using the credentials read from
 	// our Provider's Secret.
-	client := c.newS3Client(ctx, string(s.Data[p.Spec.Secret.Key]), nil)
+	client := c.newS3Client(ctx, cfg, nil)
 	ext := &external{
+		kube:     c.client,
 		s3Client: client,
 	}
 	return ext, nil
 }
 
 type external struct {
+	kube     client.Client
 	s3Client s3.Service
 }
 
@@ -123,9 +141,13 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (resource.E
 	}
 	log.Info("Observe", "bucket", bucket.Name)
 
+	if err := e.resolveObjectsUser(ctx, bucket); err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
 	bucketName := meta.GetExternalName(bucket)
 
-	bucketUser, err := e.s3Client.GetBucketInfo(ctx, bucket.Status.AtProvider.ObjectUserID, bucketName, bucket.Spec.ForProvider.Region)
+	bucketInfo, err := e.s3Client.GetBucketInfo(ctx, bucket.Status.AtProvider.ObjectUserID, bucketName, bucket.Spec.ForProvider.Region)
 
 	// If we encounter an error indicating the external resource does not exist
 	// we want to let the resource.ManagedReconciler know so it can create it.
@@ -152,25 +174,34 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (resource.E
 		bucket.SetConditions(runtimev1alpha1.Deleting())
 	}
 
-	accessKey, secretKey, err := s3.GetKeys(bucketUser)
+	accessKey, secretKey, err := s3.GetKeysFor(bucketInfo.User, bucket.Status.AtProvider.AccessKey)
 	if err != nil {
 		return resource.ExternalObservation{}, err
 	}
-	bucket.Status.AtProvider.ObjectUserID = bucketUser.ID
+	bucket.Status.AtProvider.ObjectUserID = bucketInfo.User.ID
+	bucket.Status.AtProvider.AccessKey = accessKey
 	bucket.Status.Status = statusOnline
 
+	if err := e.measureUsage(ctx, bucket); err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, "cannot measure bucket usage")
+	}
+
+	credentialsUpToDate, err := e.observeAccessCredentials(ctx, bucket)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+	policy, err := accessPolicy(bucket)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
 	// Finally, we report what we know about the external resource. Any
 	// ConnectionDetails we return will be published to the managed resource's
 	// connection secret if it specified one.
 	o := resource.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
-		ConnectionDetails: resource.ConnectionDetails{
-			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(accessKey),
-			runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(secretKey),
-			runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(fmt.Sprintf(s3.S3EndpointFormat, bucket.Spec.ForProvider.Region)),
-			resourceCredentialsSecretBucketname:                  []byte(bucketName),
-		},
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate(bucket, policy, bucketInfo) && credentialsUpToDate && !keyRotationDue(bucket, time.Now()),
+		ConnectionDetails: connectionDetails(bucket, e.s3Client, accessKey, secretKey),
 	}
 
 	return o, nil
@@ -185,28 +216,39 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (resource.Ex
 		return resource.ExternalCreation{}, errors.New(errNotInstance)
 	}
 	log.Info("Create", "bucket", bucket.Name)
+
+	// Adopted buckets must already exist.
+	if isAdopted(bucket) {
+		bucket.SetConditions(adoptFailed(reasonAdoptNotFound))
+		return resource.ExternalCreation{}, errors.New(errAdoptNotFound)
+	}
 	bucket.Status.Status = statusCreating
 
-	bucketName := meta.GetExternalName(bucket)
-	objectUser, err := e.s3Client.CreateOrUpdateBucket(ctx, bucket.Status.AtProvider.ObjectUserID, bucketName, bucket.Spec.ForProvider.Region, bucket.Spec.ForProvider.CannedACL, bucket.Spec.ForProvider.Tags)
+	if err := e.resolveObjectsUser(ctx, bucket); err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	objectUser, err := e.createOrUpdateBucket(ctx, bucket)
 	if err != nil {
 		return resource.ExternalCreation{}, errors.Wrap(err, "cannot create bucket")
 	}
 
 	bucket.Status.AtProvider.ObjectUserID = objectUser.ID
 
-	accessKey, secretKey, err := s3.GetKeys(objectUser)
-	if err != nil {
+	if err := e.reconcileAccessCredentials(ctx, bucket); err != nil {
 		return resource.ExternalCreation{}, err
 	}
-	cn := resource.ConnectionDetails{
-		runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(accessKey),
-		runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(secretKey),
-		runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(fmt.Sprintf(s3.S3EndpointFormat, bucket.Spec.ForProvider.Region)),
-		resourceCredentialsSecretBucketname:                  []byte(bucketName),
+	if err := e.configureBucket(ctx, bucket); err != nil {
+		return resource.ExternalCreation{}, err
 	}
 
-	return resource.ExternalCreation{ConnectionDetails: cn}, nil
+	accessKey, secretKey, err := s3.GetKeys(objectUser)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+	bucket.Status.AtProvider.AccessKey = accessKey
+	// A fresh key needs no rotation, even if the annotation is already set.
+	bucket.Status.AtProvider.LastKeyRotationTrigger = bucket.GetAnnotations()[storagev1alpha1.AnnotationRotateKeys]
+	return resource.ExternalCreation{ConnectionDetails: connectionDetails(bucket, e.s3Client, accessKey, secretKey)}, nil
 }
 
 // Update the existing external resource to match the specifications of our
@@ -218,9 +260,26 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (resource.Ex
 		return resource.ExternalUpdate{}, errors.New(errNotInstance)
 	}
 	log.Info("Update", "bucket", bucket.Name)
-	objectUser, err := e.s3Client.CreateOrUpdateBucket(ctx, bucket.Status.AtProvider.ObjectUserID, meta.GetExternalName(bucket), bucket.Spec.ForProvider.Region, bucket.Spec.ForProvider.CannedACL, bucket.Spec.ForProvider.Tags)
+	if err := e.resolveObjectsUser(ctx, bucket); err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+	objectUser, err := e.createOrUpdateBucket(ctx, bucket)
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update instance")
+	}
 	bucket.Status.AtProvider.ObjectUserID = objectUser.ID
-	return resource.ExternalUpdate{}, errors.Wrap(err, "cannot update instance")
+	if err := e.reconcileAccessCredentials(ctx, bucket); err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+	if err := e.configureBucket(ctx, bucket); err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	accessKey, secretKey, err := e.rotateKeys(ctx, bucket, objectUser)
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, "cannot rotate keys")
+	}
+	return resource.ExternalUpdate{ConnectionDetails: connectionDetails(bucket, e.s3Client, accessKey, secretKey)}, nil
 }
 
 // Delete the external resource. resource.ManagedReconciler only calls Delete
@@ -231,13 +290,413 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotInstance)
 	}
 	log.Info("Delete", "bucket", bucket.Name)
+
+	// Refuse to delete protected buckets, the finalizer keeps the S3Bucket
+	// around until the protection is lifted.
+	if isDeletionProtected(bucket) {
+		bucket.SetConditions(deletionProtected())
+		return errors.New(errDeletionProtected)
+	}
+
 	// Indicate that we're about to delete the instance.
 	bucket.Status.Status = statusDeleting
 
 	// Delete the instance.
-	err := e.s3Client.DeleteBucket(ctx, bucket.Status.AtProvider.ObjectUserID, meta.GetExternalName(bucket), bucket.Spec.ForProvider.Region)
+	err := e.s3Client.DeleteBucket(ctx, bucket.Status.AtProvider.ObjectUserID, meta.GetExternalName(bucket), bucket.Spec.ForProvider.Region, bucket.Spec.ForProvider.ForceDestroy)
 	if err != nil && !s3.IsErrorNotFound(err) {
 		return errors.Wrap(err, "cannot delete instance")
 	}
+	if err := e.deleteAccessCredentials(ctx, bucket); err != nil {
+		return err
+	}
+
+	// Referenced objects users are managed by their own ObjectsUser, adopted
+	// ones aren't managed at all.
+	if !ownsObjectsUser(bucket) {
+		return nil
+	}
+	err = e.s3Client.DeleteObjectsUser(ctx, bucket.Status.AtProvider.ObjectUserID)
+	if err != nil && !s3.IsErrorNotFound(err) {
+		return errors.Wrap(err, "cannot delete objects user")
+	}
+	return nil
+}
+
+// resolveObjectsUser sets the objects user ID of a bucket which references an
+// ObjectsUser, or of a bucket to adopt once its ownership is verified.
+func (e *external) resolveObjectsUser(ctx context.Context, bucket *storagev1alpha1.S3Bucket) error {
+	ref := bucket.Spec.ForProvider.ObjectsUserRef
+	if ref == nil {
+		return e.adoptBucket(ctx, bucket)
+	}
+	user := &storagev1alpha1.ObjectsUser{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, user); err != nil {
+		return errors.Wrap(err, "cannot get referenced ObjectsUser")
+	}
+	if user.Status.AtProvider.ID == "" {
+		return errors.Errorf("referenced ObjectsUser %s is not ready", ref.Name)
+	}
+	bucket.Status.AtProvider.ObjectUserID = user.Status.AtProvider.ID
+	return nil
+}
+
+// adoptBucket verifies that the objects user named by the adoption annotation
+// owns the bucket and sets its ID. The ownership is only verified once.
+func (e *external) adoptBucket(ctx context.Context, bucket *storagev1alpha1.S3Bucket) error {
+	user := bucket.GetAnnotations()[storagev1alpha1.AnnotationAdoptObjectsUser]
+	if user == "" || bucket.Status.AtProvider.ObjectUserID != "" {
+		return nil
+	}
+
+	objectsUser, err := e.s3Client.AdoptBucket(ctx, user, meta.GetExternalName(bucket), bucket.Spec.ForProvider.Region)
+	switch {
+	case s3.IsErrorNotFound(err):
+		bucket.SetConditions(adoptFailed(reasonAdoptNotFound))
+		return errors.Wrap(err, errAdoptNotFound)
+	case s3.IsErrorNotOwned(err):
+		bucket.SetConditions(adoptFailed(reasonAdoptNotOwned))
+		return errors.Wrapf(err, "cannot adopt bucket with objects user %s", user)
+	case err != nil:
+		return errors.Wrap(err, "cannot adopt bucket")
+	}
+	bucket.Status.AtProvider.ObjectUserID = objectsUser.ID
 	return nil
 }
+
+// createOrUpdateBucket creates or updates the bucket and returns the objects
+// user owning it. The objects user is only created or updated if it is owned
+// by the bucket.
+func (e *external) createOrUpdateBucket(ctx context.Context, bucket *storagev1alpha1.S3Bucket) (*cloudscale.ObjectsUser, error) {
+	p := bucket.Spec.ForProvider
+	userID := bucket.Status.AtProvider.ObjectUserID
+	bucketName := meta.GetExternalName(bucket)
+
+	if ownsObjectsUser(bucket) {
+		return e.s3Client.CreateOrUpdateBucket(ctx, userID, bucketName, p.Region, p.CannedACL, p.Tags)
+	}
+	if isManaged(bucket, p.CannedACL != nil) {
+		if err := e.s3Client.CreateOrUpdateUserBucket(ctx, userID, bucketName, p.Region, p.CannedACL); err != nil {
+			return nil, err
+		}
+	}
+	return e.s3Client.GetObjectsUser(ctx, userID, "")
+}
+
+// measureUsage updates the usage of the bucket in its status if the last
+// measurement is older than the measurement interval.
+func (e *external) measureUsage(ctx context.Context, bucket *storagev1alpha1.S3Bucket) error {
+	limit := int64(defaultUsageSampleLimit)
+	if bucket.Spec.ForProvider.UsageSampleLimit != nil {
+		limit = *bucket.Spec.ForProvider.UsageSampleLimit
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	o := &bucket.Status.AtProvider
+	if o.LastMeasured != nil && time.Since(o.LastMeasured.Time) < usageMeasurementInterval {
+		return nil
+	}
+
+	usage, err := e.s3Client.GetBucketUsage(ctx, o.ObjectUserID, meta.GetExternalName(bucket), bucket.Spec.ForProvider.Region, limit)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	o.SizeBytes = usage.SizeBytes
+	o.ObjectCount = usage.ObjectCount
+	o.UsageTruncated = usage.Truncated
+	o.LastMeasured = &now
+	return nil
+}
+
+// rotateKeys adds a new key to the objects user of the bucket if a rotation
+// was requested, and revokes the key retired by the last rotation once its
+// grace period is over. It returns the keys to publish.
+func (e *external) rotateKeys(ctx context.Context, bucket *storagev1alpha1.S3Bucket, user *cloudscale.ObjectsUser) (string, string, error) {
+	o := &bucket.Status.AtProvider
+	now := time.Now()
+
+	if retiredKeyRevocationDue(bucket, now) {
+		err := e.s3Client.DeleteObjectsUserKey(ctx, o.ObjectUserID, o.RetiredAccessKey)
+		if err != nil && !s3.IsErrorNotFound(err) {
+			return "", "", err
+		}
+		o.RetiredAccessKey = ""
+		o.RetiredKeyRevocation = nil
+	}
+
+	if !keyRotationRequested(bucket, now) {
+		return s3.GetKeysFor(user, o.AccessKey)
+	}
+
+	accessKey, secretKey, err := e.s3Client.CreateObjectsUserKey(ctx, o.ObjectUserID)
+	if err != nil {
+		return "", "", err
+	}
+	gracePeriod := defaultKeyRotationGracePeriod
+	if r := bucket.Spec.ForProvider.KeyRotation; r != nil && r.GracePeriod != nil {
+		gracePeriod = r.GracePeriod.Duration
+	}
+	rotated := metav1.NewTime(now)
+	revocation := metav1.NewTime(now.Add(gracePeriod))
+	o.RetiredAccessKey = o.AccessKey
+	o.RetiredKeyRevocation = &revocation
+	o.AccessKey = accessKey
+	o.LastKeyRotation = &rotated
+	o.LastKeyRotationTrigger = bucket.GetAnnotations()[storagev1alpha1.AnnotationRotateKeys]
+	return accessKey, secretKey, nil
+}
+
+// keyRotationDue returns true if the keys of the bucket have to be rotated or
+// a retired key has to be revoked.
+func keyRotationDue(bucket *storagev1alpha1.S3Bucket, now time.Time) bool {
+	return retiredKeyRevocationDue(bucket, now) || keyRotationRequested(bucket, now)
+}
+
+func retiredKeyRevocationDue(bucket *storagev1alpha1.S3Bucket, now time.Time) bool {
+	o := bucket.Status.AtProvider
+	return o.RetiredAccessKey != "" && o.RetiredKeyRevocation != nil && !now.Before(o.RetiredKeyRevocation.Time)
+}
+
+// keyRotationRequested returns true if the rotate-keys annotation changed or
+// the rotation interval passed. A new rotation only starts once the key
+// retired by the previous one is revoked.
+func keyRotationRequested(bucket *storagev1alpha1.S3Bucket, now time.Time) bool {
+	o := bucket.Status.AtProvider
+	if !ownsObjectsUser(bucket) || o.RetiredAccessKey != "" {
+		return false
+	}
+	if trigger := bucket.GetAnnotations()[storagev1alpha1.AnnotationRotateKeys]; trigger != "" && trigger != o.LastKeyRotationTrigger {
+		return true
+	}
+
+	r := bucket.Spec.ForProvider.KeyRotation
+	if r == nil || r.Interval == nil {
+		return false
+	}
+	last := bucket.GetCreationTimestamp().Time
+	if o.LastKeyRotation != nil {
+		last = o.LastKeyRotation.Time
+	}
+	return now.Sub(last) >= r.Interval.Duration
+}
+
+// ownsObjectsUser returns true if the objects user of the bucket was created
+// for the bucket, i.e. it is neither referenced nor adopted.
+func ownsObjectsUser(bucket *storagev1alpha1.S3Bucket) bool {
+	return bucket.Spec.ForProvider.ObjectsUserRef == nil && !isAdopted(bucket)
+}
+
+// isManaged returns true if a bucket setting is applied and compared.
+// Adopted buckets only manage the settings explicitly set in their spec, so
+// that the existing configuration isn't reset to the defaults.
+func isManaged(bucket *storagev1alpha1.S3Bucket, set bool) bool {
+	return set || !isAdopted(bucket)
+}
+
+// isAdopted returns true if the bucket is adopted from an existing objects
+// user. Buckets referencing an ObjectsUser are never adopted.
+func isAdopted(bucket *storagev1alpha1.S3Bucket) bool {
+	return bucket.Spec.ForProvider.ObjectsUserRef == nil && bucket.GetAnnotations()[storagev1alpha1.AnnotationAdoptObjectsUser] != ""
+}
+
+// adoptFailed returns a condition that indicates the bucket could not be
+// adopted for the supplied reason.
+func adoptFailed(reason runtimev1alpha1.ConditionReason) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message: fmt.Sprintf("the bucket is adopted from the objects user in the %s annotation and is never created",
+			storagev1alpha1.AnnotationAdoptObjectsUser),
+	}
+}
+
+// isDeletionProtected returns true if the bucket must not be deleted. The
+// deletion protection annotation takes precedence over the spec.
+func isDeletionProtected(bucket *storagev1alpha1.S3Bucket) bool {
+	if v, ok := bucket.GetAnnotations()[storagev1alpha1.AnnotationDeletionProtection]; ok {
+		if protected, err := strconv.ParseBool(v); err == nil {
+			return protected
+		}
+	}
+	return bucket.Spec.ForProvider.DeletionProtection
+}
+
+// deletionProtected returns a condition that indicates the managed resource
+// is not deleted because of its deletion protection.
+func deletionProtected() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reasonDeletionProtected,
+		Message: fmt.Sprintf("deletion protection is enabled, disable spec.forProvider.deletionProtection or set the %s annotation to \"false\" to delete the bucket",
+			storagev1alpha1.AnnotationDeletionProtection),
+	}
+}
+
+// configureBucket applies the bucket settings which are managed separately
+// from the bucket and its objects user. Settings which aren't managed are left
+// untouched.
+func (e *external) configureBucket(ctx context.Context, bucket *storagev1alpha1.S3Bucket) error {
+	p := bucket.Spec.ForProvider
+	userID := bucket.Status.AtProvider.ObjectUserID
+	bucketName := meta.GetExternalName(bucket)
+
+	if p.Versioning != nil {
+		if err := e.s3Client.SetBucketVersioning(ctx, userID, bucketName, p.Region, *p.Versioning); err != nil {
+			return errors.Wrap(err, "cannot set bucket versioning")
+		}
+	}
+	if isManaged(bucket, p.LifecycleRules != nil) {
+		if err := e.s3Client.SetBucketLifecycleRules(ctx, userID, bucketName, p.Region, p.LifecycleRules); err != nil {
+			return errors.Wrap(err, "cannot set bucket lifecycle rules")
+		}
+	}
+	if isManaged(bucket, p.CORSRules != nil) {
+		if err := e.s3Client.SetBucketCORSRules(ctx, userID, bucketName, p.Region, p.CORSRules); err != nil {
+			return errors.Wrap(err, "cannot set bucket CORS rules")
+		}
+	}
+	if isManaged(bucket, hasPolicy(bucket)) {
+		policy, err := accessPolicy(bucket)
+		if err != nil {
+			return err
+		}
+		if err := e.s3Client.SetBucketPolicy(ctx, userID, bucketName, p.Region, policy); err != nil {
+			return errors.Wrap(err, "cannot set bucket policy")
+		}
+	}
+	if isManaged(bucket, p.Website != nil) {
+		if err := e.s3Client.SetBucketWebsite(ctx, userID, bucketName, p.Region, p.Website); err != nil {
+			return errors.Wrap(err, "cannot set bucket website")
+		}
+	}
+	return nil
+}
+
+// isUpToDate returns true if the observed bucket matches the desired
+// parameters and policy of the S3Bucket. Settings which aren't managed are
+// ignored.
+func isUpToDate(bucket *storagev1alpha1.S3Bucket, policy string, info *s3.BucketInfo) bool {
+	p := bucket.Spec.ForProvider
+
+	// The tags of referenced objects users are managed by their ObjectsUser,
+	// the ones of adopted objects users aren't managed at all.
+	if ownsObjectsUser(bucket) {
+		tags := map[string]string{}
+		if p.Tags != nil {
+			tags = *p.Tags
+		}
+		if !tagsEqual(tags, info.User.Tags) {
+			return false
+		}
+	}
+
+	if isManaged(bucket, p.CannedACL != nil) {
+		acl := s3.DefaultCannedACL
+		if p.CannedACL != nil {
+			acl = *p.CannedACL
+		}
+		if acl != info.CannedACL {
+			return false
+		}
+	}
+
+	if p.Versioning != nil && *p.Versioning != info.Versioning {
+		return false
+	}
+
+	if isManaged(bucket, p.LifecycleRules != nil) && !lifecycleRulesEqual(p.LifecycleRules, info.LifecycleRules) {
+		return false
+	}
+
+	if isManaged(bucket, p.CORSRules != nil) && !corsRulesEqual(p.CORSRules, info.CORSRules) {
+		return false
+	}
+
+	if isManaged(bucket, hasPolicy(bucket)) && !s3.PolicyEqual(policy, info.Policy) {
+		return false
+	}
+
+	if isManaged(bucket, p.Website != nil) && !websiteEqual(p.Website, info.Website) {
+		return false
+	}
+	return true
+}
+
+// lifecycleRulesEqual compares lifecycle rules in order, treating nil and
+// empty tags as equal.
+func lifecycleRulesEqual(a, b []storagev1alpha1.LifecycleRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ra, rb := a[i], b[i]
+		if !tagsEqual(ra.Tags, rb.Tags) {
+			return false
+		}
+		ra.Tags, rb.Tags = nil, nil
+		if !reflect.DeepEqual(ra, rb) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagsEqual treats nil and empty tags as equal, as the cloudscale API omits
+// empty tags.
+func tagsEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// corsRulesEqual compares CORS rules in order, treating nil and empty lists
+// as equal.
+func corsRulesEqual(a, b []storagev1alpha1.CORSRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ra, rb := a[i], b[i]
+		if !stringsEqual(ra.AllowedOrigins, rb.AllowedOrigins) ||
+			!stringsEqual(ra.AllowedMethods, rb.AllowedMethods) ||
+			!stringsEqual(ra.AllowedHeaders, rb.AllowedHeaders) ||
+			!stringsEqual(ra.ExposeHeaders, rb.ExposeHeaders) ||
+			!reflect.DeepEqual(ra.MaxAgeSeconds, rb.MaxAgeSeconds) {
+			return false
+		}
+	}
+	return true
+}
+
+// websiteEqual compares website configurations, treating nil and empty
+// routing rules as equal.
+func websiteEqual(a, b *storagev1alpha1.WebsiteConfiguration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.RoutingRules) == 0 && len(b.RoutingRules) == 0 {
+		return a.IndexDocument == b.IndexDocument && a.ErrorDocument == b.ErrorDocument
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
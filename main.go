@@ -21,6 +21,7 @@ import (
 
 	crossplaneapis "github.com/crossplaneio/crossplane/apis"
 	"github.com/vshn/stack-cloudscale/api"
+	"github.com/vshn/stack-cloudscale/clients"
 	"github.com/vshn/stack-cloudscale/controllers"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -40,8 +41,23 @@ func main() {
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	httpOptions := clients.DefaultHTTPOptions
+	flag.DurationVar(&httpOptions.Timeout, "api-timeout", httpOptions.Timeout,
+		"The timeout of a request to the Cloudscale API, including its retries.")
+	flag.IntVar(&httpOptions.MaxRetries, "api-max-retries", httpOptions.MaxRetries,
+		"The maximum number of retries of a failed request to the Cloudscale API.")
+	flag.DurationVar(&httpOptions.MinBackoff, "api-min-backoff", httpOptions.MinBackoff,
+		"The delay before the first retry of a failed request to the Cloudscale API.")
+	flag.DurationVar(&httpOptions.MaxBackoff, "api-max-backoff", httpOptions.MaxBackoff,
+		"The maximum delay between two retries of a failed request to the Cloudscale API.")
+	flag.Float64Var(&httpOptions.RateLimit, "api-rate-limit", httpOptions.RateLimit,
+		"The number of requests per second sent to the Cloudscale API with the same token.")
+	flag.IntVar(&httpOptions.Burst, "api-burst", httpOptions.Burst,
+		"The number of requests sent to the Cloudscale API with the same token in a burst.")
 	flag.Parse()
 
+	clients.SetHTTPOptions(httpOptions)
+
 	ctrl.SetLogger(zap.Logger(true))
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{